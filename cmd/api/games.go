@@ -2,6 +2,7 @@ package main
 
 import (
 	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/gamehub"
 	"ScoreTableApi/internal/validator"
 	"errors"
 	"fmt"
@@ -212,10 +213,10 @@ var upgrader = websocket.Upgrader{
 }
 
 func (app *application) StartGame(w http.ResponseWriter, r *http.Request) {
-	//userID := app.contextGetUser(r).ID
+	userID := app.contextGetUser(r).ID
 	pin := strings.ToLower(chi.URLParam(r, "id"))
 
-	g, err := app.models.Games.Get(7, pin)
+	g, err := app.models.Games.GetByPin(pin)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -223,21 +224,30 @@ func (app *application) StartGame(w http.ResponseWriter, r *http.Request) {
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
+		return
 	}
 
-	h, err := app.gameHubs.StartGame(g)
+	h, err := app.gameHubs.StartGame(g, userID)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		switch {
+		case errors.Is(err, gamehub.ErrKeeperNotAuthorized):
+			app.notPermittedResponse(w, r)
+		case errors.Is(err, gamehub.ErrTwoTeams), errors.Is(err, gamehub.ErrGameFinished):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
 	}
-	// Get game from db and send
-	// create game stat object and hub
-	// Accept message
-	// Update game stat in hub
-	// Send updates to all clients
-	// Send event to DB concurrently
-	err = h.JoinKeeper(7, w, r)
+
+	err = h.JoinKeeper(userID, w, r)
 	if err != nil {
-		h.Errors <- err
+		switch {
+		case errors.Is(err, gamehub.ErrKeeperNotAuthorized):
+			app.notPermittedResponse(w, r)
+		default:
+			app.logError(r, err)
+		}
 	}
 }
 
@@ -250,3 +260,89 @@ func (app *application) WatchGame(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf(err.Error())
 	}
 }
+
+func (app *application) StreamGame(w http.ResponseWriter, r *http.Request) {
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	err := app.gameHubs.WatcherStreamGame(pin, w, r)
+	if err != nil {
+		switch {
+		case errors.Is(err, gamehub.ErrGameNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+	}
+}
+
+func (app *application) GetGamePlays(w http.ResponseWriter, r *http.Request) {
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	plays, err := app.gameHubs.GetPlays(pin)
+	if err != nil {
+		switch {
+		case errors.Is(err, gamehub.ErrGameNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, gamehub.ErrTwoTeams):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"plays": plays}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) GetGameLineupStats(w http.ResponseWriter, r *http.Request) {
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	lineups, err := app.gameHubs.GetLineupStats(pin)
+	if err != nil {
+		switch {
+		case errors.Is(err, gamehub.ErrGameNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, gamehub.ErrTwoTeams):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"lineups": lineups}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) GetGameShots(w http.ResponseWriter, r *http.Request) {
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	qs := r.URL.Query()
+	filter := gamehub.ShotFilter{
+		PlayerPin: strings.ToLower(app.readString(qs, "player_pin", "")),
+		TeamPin:   strings.ToLower(app.readString(qs, "team_pin", "")),
+	}
+
+	shots, zones, err := app.gameHubs.GetShots(pin, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, gamehub.ErrGameNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, gamehub.ErrTwoTeams):
+			app.badRequestResponse(w, r, err)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"shots": shots, "zones": zones}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/validator"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (app *application) GetGameKeepers(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUser(r).ID
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	game, err := app.models.Games.Get(userID, pin)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	keepers, err := app.models.GameKeepers.GetAllForGame(game.ID)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"keepers": keepers}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) InviteGameKeeper(w http.ResponseWriter, r *http.Request) {
+	owner := app.contextGetUser(r)
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	var input struct {
+		Email string           `json:"email"`
+		Role  *data.KeeperRole `json:"role"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	role := data.KeeperRoleAll
+	if input.Role != nil {
+		role = *input.Role
+	}
+
+	v := validator.New()
+	data.ValidateEmail(v, input.Email)
+	if data.ValidateKeeperRole(v, role); !v.Valid() {
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	game, err := app.models.Games.Get(owner.ID, pin)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	keeper, err := app.models.Users.GetByEmail(input.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("email", "no user with this email address exists")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+	if keeper.ID == owner.ID {
+		v.AddError("email", "cannot invite the owner of the game")
+		app.failedValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.GameKeepers.Insert(game.ID, keeper.ID, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateKeeper):
+			v.AddError("email", "user is already a keeper for this game")
+			app.failedValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.gameHubs.AllowKeeper(game.PinID.Pin, keeper.ID, role)
+
+	app.backgroundTask(func() {
+		emailData := map[string]any{
+			"gamePin":   game.PinID.Pin,
+			"ownerName": fmt.Sprintf("%s %s", owner.FirstName, owner.LastName),
+			"role":      role,
+		}
+		err := app.mailer.Send(keeper.Email, "game_keeper_invite.gohtml", emailData)
+		if err != nil {
+			app.logger.PrintError(err, nil)
+		}
+	})
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{
+		"keeper": data.GameKeeper{User: keeper, Role: role}}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) RevokeGameKeeper(w http.ResponseWriter, r *http.Request) {
+	userID := app.contextGetUser(r).ID
+	pin := strings.ToLower(chi.URLParam(r, "id"))
+
+	keeperID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	game, err := app.models.Games.Get(userID, pin)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.GameKeepers.Delete(game.ID, keeperID)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.gameHubs.RevokeKeeper(game.PinID.Pin, keeperID)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{
+		"message": fmt.Sprintf("keeper (%d) successfully revoked from game (%s)", keeperID,
+			pin)}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
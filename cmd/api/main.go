@@ -46,13 +46,14 @@ type config struct {
 	cors struct {
 		trustedOrigins []string
 	}
+	hubIdleTimeout time.Duration
 }
 
 type application struct {
 	logger   *jsonlog.Logger
 	config   config
 	models   data.Models
-	gameHubs gamehub.HubModel
+	gameHubs *gamehub.HubModel
 	mailer   mailer.Mailer
 	wg       sync.WaitGroup
 }
@@ -96,6 +97,10 @@ func main() {
 		return nil
 	})
 
+	// Game hubs
+	flag.DurationVar(&cfg.hubIdleTimeout, "hub-idle-timeout", 30*time.Minute,
+		"Stop game hubs with no keepers connected for this long (0 to disable)")
+
 	// Version
 	displayVersion := flag.Bool("version", false, "Show API version and immediately exit")
 
@@ -137,9 +142,17 @@ func main() {
 			cfg.smtp.sender),
 	}
 
-	hubModel := gamehub.NewModel(&app.models.Games)
+	hubModel := gamehub.NewModel(&app.models, logger)
 	app.gameHubs = hubModel
 
+	err = app.gameHubs.RestoreActive()
+	if err != nil {
+		logger.PrintError(err, nil)
+	}
+	if cfg.hubIdleTimeout > 0 {
+		go app.gameHubs.RunReaper(cfg.hubIdleTimeout)
+	}
+
 	//go func() {
 	//	for {
 	//		fmt.Printf("%+v\n", app.gameH)
@@ -64,8 +64,17 @@ func (app *application) routes() http.Handler {
 	router.With(app.requireActivatedUser).Get("/v1/game", app.GetAllGames)
 	router.With(app.requireAuthenticatedUser).Patch("/v1/game/{id}", app.UpdateGame)
 
-	router.Get("/v1/game/start/{id}", app.StartGame)
+	router.With(app.requireActivatedUser).Get("/v1/game/{id}/keepers", app.GetGameKeepers)
+	router.With(app.requireActivatedUser).Post("/v1/game/{id}/keepers", app.InviteGameKeeper)
+	router.With(app.requireActivatedUser).Delete("/v1/game/{id}/keepers/{user_id}",
+		app.RevokeGameKeeper)
+
+	router.With(app.requireActivatedUser).Get("/v1/game/start/{id}", app.StartGame)
 	router.Get("/v1/game/view/{id}", app.WatchGame)
+	router.Get("/v1/game/{id}/events", app.StreamGame)
+	router.Get("/v1/game/{id}/plays", app.GetGamePlays)
+	router.Get("/v1/game/{id}/lineups", app.GetGameLineupStats)
+	router.Get("/v1/game/{id}/shots", app.GetGameShots)
 
 	return router
 }
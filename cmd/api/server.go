@@ -34,7 +34,16 @@ func (app *application) serve() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer cancel()
 
-		err := srv.Shutdown(ctx)
+		// Hubs are stopped first, so event streams to watchers end and do not hold up the server.
+		app.logger.PrintInfo("stopping game hubs", map[string]string{
+			"addr": srv.Addr,
+		})
+		err := app.gameHubs.Shutdown(ctx)
+		if err != nil {
+			app.logger.PrintError(err, nil)
+		}
+
+		err = srv.Shutdown(ctx)
 		if err != nil {
 			shutdownError <- err
 		}
@@ -7,34 +7,73 @@ import (
 	"math"
 	"strconv"
 	strings2 "strings"
+	"sync"
 	"time"
 )
 
-var ErrInvalidDuration = errors.New("invalid clock duration string")
+const (
+	// tenth is how often a running game clock counts down.
+	tenth = 100 * time.Millisecond
+
+	// DefaultTenthsThreshold is the game clock time under which the clock shows tenths of a
+	// second, if Config does not set one.
+	DefaultTenthsThreshold = time.Minute
+
+	// ModeStop and ModeRunning are the values of ModeChange events.
+	ModeStop    = "stop"
+	ModeRunning = "running"
+)
 
-// Duration represents a string in the format "MM:SS"
+var (
+	ErrInvalidDuration = errors.New("invalid clock duration string")
+	ErrClockRunning    = errors.New("clock must be stopped")
+	ErrInvalidPeriod   = errors.New("period must be 1 or greater")
+	ErrControlIgnored  = errors.New("control has no effect on the clock in its current state")
+	ErrNoTimeoutsLeft  = errors.New("no timeouts left")
+	ErrPeriodStarted   = errors.New("period cannot be changed once its clock has started")
+	ErrRunningClock    = errors.New("clock runs through stoppages and cannot be paused")
+)
+
+// Duration represents a string in the format "MM:SS", or "MM:SS.t" or "SS.t" with tenths of a
+// second.
 type Duration string
 
-// ToDuration converts string from format "MM:SS" to a time.Duration
+// ToDuration converts string from format "MM:SS", "MM:SS.t" or "SS.t" to a time.Duration
 func (cd Duration) ToDuration() (time.Duration, error) {
-	strings := strings2.Split(string(cd), ":")
-	minutes, err := strconv.Atoi(strings[0])
+	whole, fraction, hasTenths := strings2.Cut(string(cd), ".")
+	var tenths int
+	if hasTenths {
+		if len(fraction) != 1 {
+			return 0, ErrInvalidDuration
+		}
+		var err error
+		tenths, err = strconv.Atoi(fraction)
+		if err != nil {
+			return 0, errors.Join(ErrInvalidDuration, err)
+		}
+	}
+
+	mins, secs, hasMinutes := strings2.Cut(whole, ":")
+	if !hasMinutes {
+		if !hasTenths {
+			return 0, ErrInvalidDuration
+		}
+		mins, secs = "0", whole
+	}
+	minutes, err := strconv.Atoi(mins)
 	if err != nil {
 		return 0, errors.Join(ErrInvalidDuration, err)
 	}
-	seconds, err := strconv.Atoi(strings[1])
+	seconds, err := strconv.Atoi(secs)
 	if err != nil {
 		return 0, errors.Join(ErrInvalidDuration, err)
 	}
-	if seconds >= 60 {
+	if minutes < 0 || seconds < 0 || seconds >= 60 || tenths < 0 {
 		return 0, ErrInvalidDuration
 	}
 
-	dur, err := time.ParseDuration(fmt.Sprintf("%dm%ds", minutes, seconds))
-	if err != nil {
-		return 0, errors.Join(ErrInvalidDuration, err)
-	}
-
+	dur := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second +
+		time.Duration(tenths)*tenth
 	return dur, nil
 }
 
@@ -47,192 +86,622 @@ const (
 	StateDone
 	StateClosed
 	StateTimeout
+	StateBreak
 )
 
-// GameClock keeps current game time and period. Sends string every second on C with current time
-// when GameClock is running.
+// Position is where a GameClock stands: its period, the time left on the game clock and its
+// state, read together.
+type Position struct {
+	Period    int64
+	Remaining time.Duration
+	State     State
+}
+
+// GameClock keeps current game time and period. A single goroutine owns the clock's state: controls
+// are received on Controller, and events, such as a Tick every second while the clock runs, are
+// sent on C. Events are queued rather than blocking the clock, so controls are always received.
+// The getters read a snapshot of the state, published by the clock after each change.
 type GameClock struct {
-	current    time.Duration
-	toCurrent  time.Duration
 	C          chan Event
 	Controller chan Control
-	state      State
-	period     int64
 	config     Config
-	stop       chan bool
-	homeTOs    int
-	awayTOs    int
+	time       TimeSource
+
+	// Owned by the run goroutine.
+	current   time.Duration
+	toCurrent time.Duration
+	state     State
+	period    int64
+	timeouts  [2]timeoutsUsed
+	shotClock time.Duration
+	breakLeft time.Duration
+	margin    int
+	stopClock bool
+	muted     bool
+	ticker    Ticker
+	ticks     <-chan time.Time
+	pending   []Event
+
+	requests  chan func()
+	quit      chan struct{}
+	exited    chan struct{}
+	closeOnce sync.Once
+
+	mu   sync.RWMutex
+	snap snapshot
+}
+
+// timeoutsUsed counts the full and short timeouts each team has used in a half of the game, or in
+// the whole game if timeouts are not given back at halftime.
+type timeoutsUsed struct {
+	home      int
+	away      int
+	homeShort int
+	awayShort int
+}
+
+// snapshot is a copy of the state of a GameClock that is safe to read outside its goroutine.
+// timeouts are those of the current half.
+type snapshot struct {
+	state     State
+	current   time.Duration
+	toCurrent time.Duration
+	period    int64
+	timeouts  timeoutsUsed
+	shotClock time.Duration
+	breakLeft time.Duration
+	stopClock bool
 }
 
 func (gc *GameClock) run() {
 	for {
+		// C is only selected when an event is queued, as sending on a nil channel blocks.
+		var out chan Event
+		var next Event
+		if len(gc.pending) > 0 {
+			out = gc.C
+			next = gc.pending[0]
+		}
+
 		select {
-		case action, ok := <-gc.Controller:
-			if !ok {
-				return
-			}
-			switch action {
-			case Play:
-				gc.Play()
-			case Pause:
-				gc.Pause()
-			case Reset:
-				gc.Reset()
-			case AddMin:
-				gc.Adjust("01:00", true)
-			case SubtractMin:
-				gc.Adjust("01:00", false)
-			case AddSec:
-				gc.Adjust("00:01", true)
-			case SubtractSec:
-				gc.Adjust("00:01", false)
-			case AddPeriod:
-				gc.ChangePeriod(1)
-			case SubtractPeriod:
-				gc.ChangePeriod(-1)
-			case CallTimeoutHome:
-				gc.Timeout(data.TeamHome)
-			case CallTimeoutAway:
-				gc.Timeout(data.TeamAway)
-			case EndTimeout:
-				gc.Controller <- EndTimeout
-			default:
-			}
+		case action := <-gc.Controller:
+			gc.control(action)
+		case f := <-gc.requests:
+			f()
+		case <-gc.ticks:
+			gc.tick()
+		case out <- next:
+			gc.pending = gc.pending[1:]
+		case <-gc.quit:
+			gc.stopTicker()
+			gc.state = StateClosed
+			gc.pending = nil
+			gc.publish()
+			close(gc.C)
+			close(gc.exited)
+			return
 		}
+		gc.publish()
 	}
 }
 
-func (gc *GameClock) GetState() State {
-	return gc.state
+// control applies action, returning an error if it had no effect.
+func (gc *GameClock) control(action Control) error {
+	switch action {
+	case Play:
+		return gc.play()
+	case Pause:
+		return gc.pause()
+	case Reset:
+		return gc.reset()
+	case AddMin:
+		return gc.adjust(time.Minute)
+	case SubtractMin:
+		return gc.adjust(-time.Minute)
+	case AddSec:
+		return gc.adjust(time.Second)
+	case SubtractSec:
+		return gc.adjust(-time.Second)
+	case AddPeriod:
+		return gc.changePeriod(1)
+	case SubtractPeriod:
+		return gc.changePeriod(-1)
+	case CallTimeoutHome:
+		return gc.timeout(data.TeamHome, false)
+	case CallTimeoutAway:
+		return gc.timeout(data.TeamAway, false)
+	case CallShortTimeoutHome:
+		return gc.timeout(data.TeamHome, true)
+	case CallShortTimeoutAway:
+		return gc.timeout(data.TeamAway, true)
+	case ResetShotClock:
+		return gc.resetShotClock(false)
+	case ResetShotClockShort:
+		return gc.resetShotClock(true)
+	case EndTimeout:
+		return gc.endTimeout()
+	case StartBreak:
+		return gc.startBreak()
+	case EndBreak:
+		return gc.endBreak()
+	default:
+		return ErrControlIgnored
+	}
+}
+
+// apply applies action, with value for SetClock and SetPeriod, returning an error if it had no
+// effect.
+func (gc *GameClock) apply(action Control, value string) error {
+	switch action {
+	case SetClock:
+		duration, err := Duration(value).ToDuration()
+		if err != nil {
+			return err
+		}
+		return gc.set(duration)
+	case SetPeriod:
+		period, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || period <= 0 {
+			return ErrInvalidPeriod
+		}
+		return gc.setPeriod(period)
+	default:
+		return gc.control(action)
+	}
+}
+
+// Apply applies action to the GameClock, with value for SetClock and SetPeriod, and waits for it
+// to be applied. It returns the Position of the GameClock from just before action, which is what
+// a logged control is replayed from, or an error if action had no effect.
+func (gc *GameClock) Apply(action Control, value string) (Position, error) {
+	var pos Position
+	err := ErrClockRunning
+	gc.do(func() {
+		pos = Position{Period: gc.period, Remaining: gc.current, State: gc.state}
+		err = gc.apply(action, value)
+	})
+	return pos, err
 }
 
-func (gc *GameClock) Timeout(side data.GameTeamSide) {
+// tick counts down the game clock, or the timeout if one is running.
+func (gc *GameClock) tick() {
 	switch gc.state {
-	case StateClosed:
-		return
+	case StatePlaying:
+		gc.current -= tenth
+		if gc.current <= 0 {
+			gc.done()
+			return
+		}
+		// Ticks are sent every second, or every tenth under the threshold.
+		if gc.current < gc.config.TenthsThreshold || gc.current%time.Second == 0 {
+			gc.emit(Event{
+				EventType: Tick,
+				Value:     gc.get(),
+			})
+		}
+		gc.updateMode()
+		if gc.tickShotClock() && gc.stopClock {
+			// A shot clock violation stops play, unless the clock is running through stoppages.
+			gc.stopTicker()
+			gc.state = StatePaused
+			gc.emit(Event{
+				EventType: Transport,
+				Value:     "",
+			})
+		}
+	case StateTimeout:
+		gc.toCurrent -= time.Second
+		if gc.toCurrent <= 0 {
+			gc.endTimeout()
+			return
+		}
+		gc.emit(Event{
+			EventType: Tick,
+			Value:     gc.get(),
+		})
+	case StateBreak:
+		gc.breakLeft -= time.Second
+		if gc.breakLeft <= 0 {
+			gc.endBreak()
+			return
+		}
+		gc.emit(Event{
+			EventType: BreakTick,
+			Value:     formatDuration(gc.breakLeft, false),
+		})
 	default:
-		gc.stop <- true
-		timeoutRoutine := func() {
-			gc.state = StateTimeout
+	}
+}
 
-			ticker := time.NewTicker(time.Second)
-			defer ticker.Stop()
+func (gc *GameClock) startTicker(d time.Duration) {
+	gc.stopTicker()
+	gc.ticker = gc.time.NewTicker(d)
+	gc.ticks = gc.ticker.C()
+}
 
-			gc.C <- Event{
-				EventType: Timeout,
-				Value:     gc.Get(),
-			}
+func (gc *GameClock) stopTicker() {
+	if gc.ticker == nil {
+		return
+	}
+	gc.ticker.Stop()
+	gc.ticker = nil
+	gc.ticks = nil
+}
 
-			for {
-				select {
-				case control := <-gc.Controller:
-					if control == EndTimeout {
-						gc.state = StatePaused
-						gc.toCurrent = gc.config.TimeoutDuration
-						gc.C <- Event{
-							EventType: TimeoutDone,
-							Value:     gc.Get(),
-						}
-						return
-					}
-				case <-ticker.C:
-					gc.toCurrent -= time.Second
-					if gc.toCurrent > 0 {
-						gc.C <- Event{
-							EventType: Tick,
-							Value:     gc.Get(),
-						}
-					} else {
-						go gc.done()
-						return
-					}
-
-				}
-			}
+// emit queues e to be sent on C, with the time left on the game clock, unless the GameClock is
+// being replayed.
+func (gc *GameClock) emit(e Event) {
+	if gc.muted {
+		return
+	}
+	e.Remaining = gc.current
+	gc.pending = append(gc.pending, e)
+}
+
+// publish copies the state of the GameClock to the snapshot read by its getters.
+func (gc *GameClock) publish() {
+	s := snapshot{
+		state:     gc.state,
+		current:   gc.current,
+		toCurrent: gc.toCurrent,
+		period:    gc.period,
+		timeouts:  gc.timeouts[gc.half()],
+		shotClock: gc.shotClock,
+		breakLeft: gc.breakLeft,
+		stopClock: gc.wantStopClock(),
+	}
+	gc.mu.Lock()
+	gc.snap = s
+	gc.mu.Unlock()
+}
+
+func (gc *GameClock) load() snapshot {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.snap
+}
+
+// do runs f on the GameClock's goroutine and waits for it to return, reporting false if the
+// GameClock is closed.
+func (gc *GameClock) do(f func()) bool {
+	finished := make(chan struct{})
+	select {
+	case gc.requests <- func() {
+		f()
+		close(finished)
+	}:
+		<-finished
+		return true
+	case <-gc.exited:
+		return false
+	}
+}
+
+// Restore sets a stopped GameClock to a persisted Position without sending on C. It is used to
+// rebuild a GameClock from a game's event log before anything reads from C. A restored clock is
+// always stopped, so a Position logged while the clock, a timeout or a break was running is
+// restored as paused, or done if the period had run out.
+func (gc *GameClock) Restore(pos Position) error {
+	err := ErrClockRunning
+	gc.do(func() {
+		if gc.running() {
+			return
 		}
-		switch side {
-		case data.TeamHome:
-			if gc.homeTOs < gc.config.TimeoutsAllowed {
-				gc.homeTOs++
-				go timeoutRoutine()
-				return
-			}
-		case data.TeamAway:
-			if gc.awayTOs < gc.config.TimeoutsAllowed {
-				gc.awayTOs++
-				go timeoutRoutine()
-				return
+
+		gc.period = pos.Period
+		gc.current = pos.Remaining
+		gc.state = pos.State
+		switch {
+		case pos.Remaining <= 0:
+			gc.current = 0
+			gc.state = StateDone
+		case gc.running():
+			gc.state = StatePaused
+		}
+		err = nil
+	})
+	return err
+}
+
+// Replay applies the lasting effect of a persisted Control, with value for SetClock and SetPeriod,
+// to a stopped GameClock without starting it or sending on C. It is called after the GameClock is
+// restored to the period and time logged with the control, which are from just before it.
+// Transport controls are ignored, as a replayed clock is always left stopped, and a replayed
+// StartBreak advances the period without running the break.
+func (gc *GameClock) Replay(action Control, value string) {
+	gc.do(func() {
+		if gc.running() {
+			return
+		}
+		gc.muted = true
+		defer func() {
+			gc.muted = false
+		}()
+
+		switch action {
+		case Reset, AddMin, SubtractMin, AddSec, SubtractSec, AddPeriod, SubtractPeriod,
+			ResetShotClock, ResetShotClockShort, SetClock, SetPeriod:
+			gc.apply(action, value)
+		case CallTimeoutHome:
+			gc.useTimeout(data.TeamHome, false)
+		case CallTimeoutAway:
+			gc.useTimeout(data.TeamAway, false)
+		case CallShortTimeoutHome:
+			gc.useTimeout(data.TeamHome, true)
+		case CallShortTimeoutAway:
+			gc.useTimeout(data.TeamAway, true)
+		case StartBreak:
+			// A replayed break is skipped, so the period it leads to starts right away.
+			if gc.state == StateDone && gc.breakLength() > 0 {
+				gc.setPeriod(gc.period + 1)
 			}
+		default:
 		}
+	})
+}
 
+// running reports whether the game clock, a timeout or a break is running, or the GameClock is
+// closed. The game clock time and period cannot be changed while it is.
+func (gc *GameClock) running() bool {
+	switch gc.state {
+	case StatePlaying, StateTimeout, StateBreak, StateClosed:
+		return true
+	default:
+		return false
 	}
 }
 
-func (gc *GameClock) GetTimeouts() map[string]int {
-	return map[string]int{
-		"home":    gc.homeTOs,
-		"away":    gc.awayTOs,
-		"allowed": gc.config.TimeoutsAllowed,
+func (gc *GameClock) GetState() State {
+	return gc.load().state
+}
+
+// timeout stops the clock and starts a full or short timeout for side, if side has one left.
+func (gc *GameClock) timeout(side data.GameTeamSide, short bool) error {
+	if gc.state == StateClosed || gc.state == StateTimeout || gc.state == StateBreak {
+		return ErrControlIgnored
 	}
+	if !gc.useTimeout(side, short) {
+		return ErrNoTimeoutsLeft
+	}
+	gc.toCurrent = gc.config.TimeoutDuration
+	if short {
+		gc.toCurrent = gc.config.ShortTimeoutDuration
+	}
+	gc.state = StateTimeout
+	gc.startTicker(time.Second)
+
+	gc.emit(Event{
+		EventType: Timeout,
+		Value:     gc.get(),
+	})
+	return nil
 }
 
-// Play starts game clock at current time.
-func (gc *GameClock) Play() {
-	switch gc.state {
-	case StatePlaying, StateDone, StateClosed:
-		return
+// endTimeout ends a running timeout, when it runs out or is ended early, leaving the clock
+// stopped.
+func (gc *GameClock) endTimeout() error {
+	if gc.state != StateTimeout {
+		return ErrControlIgnored
+	}
+	gc.stopTicker()
+	gc.state = StatePaused
+	if gc.current <= 0 {
+		gc.state = StateDone
+	}
+	gc.toCurrent = gc.config.TimeoutDuration
+
+	gc.emit(Event{
+		EventType: TimeoutDone,
+		Value:     gc.get(),
+	})
+	return nil
+}
+
+// startBreak starts the break after a period that has run out, if the game has one: halftime, the
+// break before overtime, or the break between other periods.
+func (gc *GameClock) startBreak() error {
+	if gc.state != StateDone {
+		return ErrControlIgnored
+	}
+	length := gc.breakLength()
+	if length <= 0 {
+		return ErrControlIgnored
+	}
+	gc.breakLeft = length
+	gc.state = StateBreak
+	gc.startTicker(time.Second)
+
+	gc.emit(Event{
+		EventType: BreakStart,
+		Value:     formatDuration(gc.breakLeft, false),
+	})
+	return nil
+}
+
+// endBreak ends a running break, when it runs out or is ended early, and starts the next period
+// with the game clock reset.
+func (gc *GameClock) endBreak() error {
+	if gc.state != StateBreak {
+		return ErrControlIgnored
+	}
+	gc.stopTicker()
+	gc.breakLeft = 0
+	gc.state = StateDone
+
+	gc.emit(Event{
+		EventType: BreakDone,
+		Value:     "",
+	})
+	return gc.setPeriod(gc.period + 1)
+}
+
+// breakLength returns the length of the break after the current period, which is 0 if there is
+// none. Halftime only falls between periods of a game with an even number of them.
+func (gc *GameClock) breakLength() time.Duration {
+	switch {
+	case gc.period >= gc.config.PeriodCount:
+		return gc.config.OtBreakDuration
+	case gc.config.PeriodCount%2 == 0 && gc.period == gc.config.PeriodCount/2:
+		return gc.config.HalftimeDuration
 	default:
-		go func() {
-			gc.state = StatePlaying
+		return gc.config.BreakDuration
+	}
+}
 
-			ticker := time.NewTicker(time.Second)
-			defer ticker.Stop()
+// GetBreak returns the time left in a running break, formatted "MM:SS", or an empty string if
+// there is no break running.
+func (gc *GameClock) GetBreak() string {
+	snap := gc.load()
+	if snap.state != StateBreak {
+		return ""
+	}
+	return formatDuration(snap.breakLeft, false)
+}
 
-			gc.C <- Event{
-				EventType: Transport,
-				Value:     gc.Get(),
-			}
+// SetMargin tells the GameClock the difference between the teams' scores, for a running clock
+// that stops through stoppages in close games.
+func (gc *GameClock) SetMargin(margin int) {
+	if margin < 0 {
+		margin = -margin
+	}
+	gc.do(func() {
+		gc.margin = margin
+	})
+}
 
-			for {
-				select {
-				case <-gc.stop:
-					return
-				case <-ticker.C:
-					gc.current -= time.Second
-					if gc.current > 0 {
-						gc.C <- Event{
-							EventType: Tick,
-							Value:     gc.Get(),
-						}
-					} else {
-						go gc.done()
-						return
-					}
-				}
-			}
-		}()
+// wantStopClock reports whether the clock stops through stoppages, which it always does unless
+// RunningClock is set in cfg. A running clock stops through stoppages under StopClockUnder in the
+// last period and overtime, and while the margin is under StopClockMargin.
+func (gc *GameClock) wantStopClock() bool {
+	if !gc.config.RunningClock {
+		return true
+	}
+	if gc.config.StopClockUnder > 0 && gc.period >= gc.config.PeriodCount &&
+		gc.current < gc.config.StopClockUnder {
+		return true
+	}
+	return gc.config.StopClockMargin > 0 && gc.margin < gc.config.StopClockMargin
+}
+
+// updateMode switches between running and stop-clock behavior, sending a ModeChange event with
+// the new mode. It is only called while the clock is playing, so a mode change waits until then.
+func (gc *GameClock) updateMode() {
+	stopClock := gc.wantStopClock()
+	if stopClock == gc.stopClock {
+		return
 	}
+	gc.stopClock = stopClock
+	gc.emit(Event{
+		EventType: ModeChange,
+		Value:     formatMode(stopClock),
+	})
 }
 
-// Pause will pause GameClock if in StatePlaying state.
-func (gc *GameClock) Pause() {
-	if gc.state == StatePlaying {
-		gc.stop <- true
-		gc.state = StatePaused
-		gc.C <- Event{
+// GetMode returns ModeStop if the clock stops through stoppages, or ModeRunning if it runs
+// through them.
+func (gc *GameClock) GetMode() string {
+	return formatMode(gc.load().stopClock)
+}
+
+func formatMode(stopClock bool) string {
+	if stopClock {
+		return ModeStop
+	}
+	return ModeRunning
+}
+
+// useTimeout counts a full or short timeout for side in the current half, reporting false if side
+// has none left.
+func (gc *GameClock) useTimeout(side data.GameTeamSide, short bool) bool {
+	used := &gc.timeouts[gc.half()]
+	var count *int
+	allowed := gc.config.TimeoutsAllowed
+	switch {
+	case side == data.TeamHome && !short:
+		count = &used.home
+	case side == data.TeamAway && !short:
+		count = &used.away
+	case side == data.TeamHome && short:
+		count = &used.homeShort
+		allowed = gc.config.ShortTimeoutsAllowed
+	case side == data.TeamAway && short:
+		count = &used.awayShort
+		allowed = gc.config.ShortTimeoutsAllowed
+	default:
+		return false
+	}
+	if *count >= allowed {
+		return false
+	}
+	*count++
+	return true
+}
+
+// half returns 0 in the first half of the game and 1 in the second half and overtime. It is always
+// 0 if timeouts are given for the whole game.
+func (gc *GameClock) half() int {
+	if gc.config.TimeoutsPerHalf && gc.config.PeriodCount >= 2 && gc.period > gc.config.PeriodCount/2 {
+		return 1
+	}
+	return 0
+}
+
+// GetTimeouts returns the full and short timeouts each team has used in the current half, and how
+// many of each are allowed.
+func (gc *GameClock) GetTimeouts() map[string]int {
+	used := gc.load().timeouts
+	return map[string]int{
+		"home":          used.home,
+		"away":          used.away,
+		"allowed":       gc.config.TimeoutsAllowed,
+		"home_short":    used.homeShort,
+		"away_short":    used.awayShort,
+		"allowed_short": gc.config.ShortTimeoutsAllowed,
+	}
+}
+
+// play starts game clock at current time. A running timeout must be ended first.
+func (gc *GameClock) play() error {
+	switch gc.state {
+	case StatePlaying, StateDone, StateClosed, StateTimeout, StateBreak:
+		return ErrControlIgnored
+	default:
+		gc.state = StatePlaying
+		gc.startTicker(tenth)
+
+		gc.emit(Event{
 			EventType: Transport,
-			Value:     "",
-		}
+			Value:     gc.get(),
+		})
+		gc.updateMode()
+		return nil
 	}
-	return
 }
 
-// Reset sets current clock duration to PeriodLength in cfg,
+// pause will pause GameClock if in StatePlaying state, unless it is running through stoppages.
+func (gc *GameClock) pause() error {
+	if gc.state != StatePlaying {
+		return ErrControlIgnored
+	}
+	gc.updateMode()
+	if !gc.stopClock {
+		return ErrRunningClock
+	}
+	gc.stopTicker()
+	gc.state = StatePaused
+	gc.emit(Event{
+		EventType: Transport,
+		Value:     "",
+	})
+	return nil
+}
+
+// reset sets current clock duration to PeriodLength in cfg,
 // or OtDuration if period is greater than PeriodCount in cfg.
-// Will return with no action if GameClock state is StatePlaying.
-func (gc *GameClock) Reset() {
-	if gc.state == StatePlaying || gc.state == StateClosed {
-		return
+// Will return with no action if the clock is running.
+func (gc *GameClock) reset() error {
+	if gc.running() {
+		return ErrClockRunning
 	}
 	if gc.period <= gc.config.PeriodCount {
 		gc.current = gc.config.PeriodLength
@@ -241,153 +710,281 @@ func (gc *GameClock) Reset() {
 	}
 	gc.state = StateFresh
 
-	gc.C <- Event{
+	gc.emit(Event{
 		EventType: ClockSet,
-		Value:     gc.Get(),
-	}
-	return
+		Value:     gc.get(),
+	})
+	return nil
 }
 
-// Set takes a ClockDuration and assigns its time.Duration as current GameClock time.
-func (gc *GameClock) Set(dur Duration) {
-	if gc.state == StatePlaying || gc.state == StateClosed {
-		return
-	}
-	duration, err := dur.ToDuration()
-	if err != nil {
-		return
+// Set sets the game clock to dur. The clock must be stopped, and not in a timeout or break.
+func (gc *GameClock) Set(dur Duration) error {
+	_, err := gc.Apply(SetClock, string(dur))
+	return err
+}
+
+func (gc *GameClock) set(duration time.Duration) error {
+	if gc.running() {
+		return ErrClockRunning
 	}
 
 	gc.current = duration
 	gc.state = StateFresh
+	if duration <= 0 {
+		gc.current = 0
+		gc.state = StateDone
+	}
 
-	gc.C <- Event{
+	gc.emit(Event{
 		EventType: ClockSet,
-		Value:     gc.Get(),
-	}
-	return
+		Value:     gc.get(),
+	})
+	return nil
 }
 
-// Adjust takes a ClockDuration and bool and adds time.Duration from ClockDuration to current
-// GameClock time if bool is true, or subtracts if bool is false.
-func (gc *GameClock) Adjust(dur Duration, add bool) {
-	if gc.state == StatePlaying || gc.state == StateClosed {
-		return
-	}
-	duration, err := dur.ToDuration()
-	if err != nil {
-		return
+// SetPeriod jumps to period, resetting the game clock and shot clock to their full lengths. The
+// clock must be stopped, and not in a timeout or break.
+func (gc *GameClock) SetPeriod(period int64) error {
+	_, err := gc.Apply(SetPeriod, strconv.FormatInt(period, 10))
+	return err
+}
+
+func (gc *GameClock) setPeriod(period int64) error {
+	if gc.running() {
+		return ErrClockRunning
 	}
 
-	if add {
-		gc.current += duration
-	} else {
-		gc.current -= duration
+	gc.period = period
+	gc.current = gc.config.PeriodLength
+	if period > gc.config.PeriodCount {
+		gc.current = gc.config.OtDuration
 	}
+	gc.shotClock = gc.config.ShotClockLength
 	gc.state = StateFresh
 
-	gc.C <- Event{
+	gc.emit(Event{
+		EventType: PeriodSet,
+		Value:     fmt.Sprintf("%d/%d", gc.period, gc.config.PeriodCount),
+	})
+	gc.emit(Event{
 		EventType: ClockSet,
-		Value:     gc.Get(),
+		Value:     gc.get(),
+	})
+	return nil
+}
+
+// adjust adds d, which is negative to subtract time, to the current GameClock time.
+func (gc *GameClock) adjust(d time.Duration) error {
+	if gc.running() {
+		return ErrClockRunning
 	}
-	return
+
+	gc.current += d
+	gc.state = StateFresh
+
+	gc.emit(Event{
+		EventType: ClockSet,
+		Value:     gc.get(),
+	})
+	return nil
 }
 
-// Get returns a string in format of "MM:SS" with current GameClock time
+// Get returns a string in format of "MM:SS" with current GameClock time, or "SS.t" once the game
+// clock is under the TenthsThreshold in cfg. A running timeout is always formatted "MM:SS".
 func (gc *GameClock) Get() string {
-	if gc.state == StateClosed {
+	return gc.load().get(gc.config)
+}
+
+// GetGameClock returns the game clock time formatted like Get, even while a timeout is running.
+func (gc *GameClock) GetGameClock() string {
+	snap := gc.load()
+	if snap.state == StateClosed {
 		return ""
 	}
+	return formatDuration(snap.current, snap.current < gc.config.TenthsThreshold)
+}
 
-	var current time.Duration
-	if gc.state == StateTimeout {
-		current = gc.toCurrent
-	} else {
-		current = gc.current
+// FormatExact formats d as "MM:SS.t", keeping the tenths of a second that Get rounds away, so a
+// GameClock restored from it is set to the same time.
+func FormatExact(d time.Duration) Duration {
+	if d < 0 {
+		d = 0
 	}
+	t := int(d / tenth)
+	return Duration(fmt.Sprintf("%02d:%02d.%d", t/600, t/10%60, t%10))
+}
 
-	mins := int(math.Floor(current.Minutes()))
-	minsDuration := time.Duration(mins) * time.Minute
-	secs := int(math.Round((current - minsDuration).Seconds()))
-	var padMin string
-	var padSec string
-	switch {
-	case mins < 10:
-		padMin = "0"
-	default:
-		padMin = ""
+// get is Get for the GameClock's own goroutine, which reads its state directly.
+func (gc *GameClock) get() string {
+	return snapshot{state: gc.state, current: gc.current, toCurrent: gc.toCurrent}.get(gc.config)
+}
+
+func (s snapshot) get(cfg Config) string {
+	if s.state == StateClosed {
+		return ""
 	}
-	switch {
-	case secs < 10:
-		padSec = "0"
-	default:
-		padSec = ""
+	if s.state == StateTimeout {
+		return formatDuration(s.toCurrent, false)
 	}
-	str := fmt.Sprintf(`%s%d:%s%d`, padMin, mins, padSec, secs)
-	return str
+	return formatDuration(s.current, s.current < cfg.TenthsThreshold)
 }
 
-// ChangePeriod sets the current GameClock period.
-// Period can only be changed on a GameClock with StateFresh or StateDone state
-func (gc *GameClock) ChangePeriod(add int64) {
-	if gc.state == StatePlaying || gc.state == StatePaused || gc.state == StateClosed {
-		return
+// formatDuration formats d as "MM:SS", rounding up to the whole second like a scoreboard, or as
+// "SS.t" if tenths is true.
+func formatDuration(d time.Duration, tenths bool) string {
+	if d < 0 {
+		d = 0
 	}
-	if gc.period+add <= 0 {
-		return
+	if tenths {
+		t := int(d / tenth)
+		return fmt.Sprintf("%02d.%d", t/10, t%10)
 	}
-	gc.period += add
-	gc.current = gc.config.PeriodLength
-	gc.C <- Event{
-		EventType: PeriodSet,
-		Value:     fmt.Sprintf("%d/%d", gc.period, gc.config.PeriodCount),
+	secs := int(math.Ceil(d.Seconds()))
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// resetShotClock sets the shot clock to its full length, or its short length if short is true. The
+// shot clock can be reset while the game clock is running.
+func (gc *GameClock) resetShotClock(short bool) error {
+	if gc.config.ShotClockLength == 0 || gc.state == StateClosed {
+		return ErrControlIgnored
+	}
+	gc.shotClock = gc.config.ShotClockLength
+	if short && gc.config.ShotClockShort > 0 {
+		gc.shotClock = gc.config.ShotClockShort
+	}
+
+	gc.emit(Event{
+		EventType: ShotClockSet,
+		Value:     formatShotClock(gc.shotClock),
+	})
+	return nil
+}
+
+// GetShotClock returns the whole seconds left on the shot clock, or an empty string if the game
+// has no shot clock.
+func (gc *GameClock) GetShotClock() string {
+	if gc.config.ShotClockLength == 0 {
+		return ""
+	}
+	return formatShotClock(gc.load().shotClock)
+}
+
+func formatShotClock(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// tickShotClock runs the shot clock down by a tenth of a second while the game clock runs, and
+// reports whether it expired. An expired shot clock stays at 0 until it is reset.
+func (gc *GameClock) tickShotClock() bool {
+	if gc.config.ShotClockLength == 0 || gc.shotClock <= 0 {
+		return false
+	}
+	gc.shotClock -= tenth
+	if gc.shotClock > 0 {
+		if gc.shotClock%time.Second != 0 {
+			return false
+		}
+		gc.emit(Event{
+			EventType: ShotClockTick,
+			Value:     formatShotClock(gc.shotClock),
+		})
+		return false
+	}
+
+	gc.emit(Event{
+		EventType: ShotClockViolation,
+		Value:     formatShotClock(gc.shotClock),
+	})
+	return true
+}
+
+// Remaining returns the time left on the game clock, even while a timeout is running.
+func (gc *GameClock) Remaining() time.Duration {
+	return gc.load().current
+}
+
+// changePeriod moves the GameClock add periods on, or back if add is negative, resetting it like
+// setPeriod. Period can only be changed on a GameClock with StateFresh or StateDone state
+func (gc *GameClock) changePeriod(add int64) error {
+	if gc.running() {
+		return ErrClockRunning
 	}
+	if gc.state == StatePaused {
+		return ErrPeriodStarted
+	}
+	if gc.period+add <= 0 {
+		return ErrInvalidPeriod
+	}
+	return gc.setPeriod(gc.period + add)
+}
+
+// Position returns the period, the time left on the game clock and the state, read together.
+func (gc *GameClock) Position() Position {
+	snap := gc.load()
+	return Position{Period: snap.period, Remaining: snap.current, State: snap.state}
 }
 
 // GetPeriod returns current GameClock period.
 func (gc *GameClock) GetPeriod() int64 {
-	if gc.state == StatePlaying {
-		return 0
-	}
-	return gc.period
+	return gc.load().period
 }
 
+// Close stops the GameClock, even while it is running, and closes C. Events not yet read from C
+// are dropped, and controls sent after Close are never received.
 func (gc *GameClock) Close() {
-	if gc.state == StatePlaying {
-		return
-	}
-	defer close(gc.C)
-	defer close(gc.stop)
-	defer close(gc.Controller)
-	gc.state = StateClosed
+	gc.closeOnce.Do(func() {
+		close(gc.quit)
+	})
+	<-gc.exited
 }
 
-// StateDone is called when GameClock current is 0 or less.
+// done is called when GameClock current is 0 or less, and starts the break after the period if
+// it is not the last of regulation.
 func (gc *GameClock) done() {
-	if gc.current > 0 {
-		gc.state = StatePaused
-		gc.toCurrent = gc.config.TimeoutDuration
-		gc.C <- Event{
-			EventType: TimeoutDone,
-			Value:     gc.Get(),
-		}
-		return // Will be greater than 0 if StateTimeout timer was used
-	}
+	gc.stopTicker()
 	gc.current = 0
 	gc.state = StateDone
-	gc.C <- Event{
+	gc.emit(Event{
 		EventType: Done,
 		Value:     "",
+	})
+	// Whether there is overtime depends on the score, so only breaks within regulation start on
+	// their own.
+	if gc.period < gc.config.PeriodCount {
+		gc.startBreak()
 	}
-	return
 }
 
 type Config struct {
-	PeriodLength    time.Duration
-	PeriodCount     int64
-	OtDuration      time.Duration
-	TimeoutDuration time.Duration
-	TimeoutsAllowed int
+	PeriodLength         time.Duration
+	PeriodCount          int64
+	OtDuration           time.Duration
+	TimeoutDuration      time.Duration
+	TimeoutsAllowed      int
+	ShortTimeoutDuration time.Duration
+	ShortTimeoutsAllowed int
+	// TimeoutsPerHalf gives each team its timeouts back at the start of the second half. Overtime
+	// periods are part of the second half.
+	TimeoutsPerHalf bool
+	// ShotClockLength is the full length of the shot clock, which is off if it is 0, and
+	// ShotClockShort the length it is reset to by ResetShotClockShort, e.g. 24 and 14 seconds.
+	ShotClockLength time.Duration
+	ShotClockShort  time.Duration
+	// BreakDuration, HalftimeDuration and OtBreakDuration are the lengths of the breaks between
+	// periods, at halftime and before each overtime period. A break of 0 is not timed.
+	BreakDuration    time.Duration
+	HalftimeDuration time.Duration
+	OtBreakDuration  time.Duration
+	// RunningClock keeps the clock running through stoppages, so Pause controls and shot clock
+	// violations do not stop it, until StopClockUnder is left in the last period, or while the
+	// margin is under StopClockMargin points. Timeouts and the end of a period still stop it.
+	RunningClock    bool
+	StopClockUnder  time.Duration
+	StopClockMargin int
+	// TenthsThreshold is the game clock time under which the clock shows and sends ticks for tenths
+	// of a second. It is DefaultTenthsThreshold if 0, and tenths are never shown if it is negative.
+	TenthsThreshold time.Duration
 }
 
 type Control int
@@ -405,6 +1002,19 @@ const (
 	CallTimeoutHome
 	CallTimeoutAway
 	EndTimeout
+	CallShortTimeoutHome
+	CallShortTimeoutAway
+	ResetShotClock
+	ResetShotClockShort
+	// SetClock and SetPeriod carry a value, so they are applied with Apply, Set or SetPeriod rather
+	// than sent on Controller, which ignores them.
+	SetClock
+	SetPeriod
+	// A break starts on its own when a period before the last runs out. StartBreak starts the break
+	// before overtime, or one after a period ended by setting the clock, and EndBreak ends a break
+	// early.
+	StartBreak
+	EndBreak
 )
 
 type EventType int
@@ -417,14 +1027,31 @@ const (
 	PeriodSet
 	Timeout
 	TimeoutDone
+	ShotClockTick
+	ShotClockSet
+	ShotClockViolation
+	BreakStart
+	BreakTick
+	BreakDone
+	ModeChange
 )
 
+// Event is sent on C. Remaining is the time left on the game clock when the event happened.
 type Event struct {
 	EventType
-	Value string
+	Value     string
+	Remaining time.Duration
 }
 
-func NewGameClock(cfg Config) *GameClock {
+// NewGameClock returns a GameClock with cfg that counts down with ts, or with SystemTime if ts is
+// nil.
+func NewGameClock(cfg Config, ts TimeSource) *GameClock {
+	if ts == nil {
+		ts = SystemTime
+	}
+	if cfg.TenthsThreshold == 0 {
+		cfg.TenthsThreshold = DefaultTenthsThreshold
+	}
 	clock := &GameClock{
 		current:    cfg.PeriodLength,
 		toCurrent:  cfg.TimeoutDuration,
@@ -432,11 +1059,15 @@ func NewGameClock(cfg Config) *GameClock {
 		period:     1,
 		C:          make(chan Event),
 		config:     cfg,
-		stop:       make(chan bool),
 		Controller: make(chan Control),
-		homeTOs:    0,
-		awayTOs:    0,
+		shotClock:  cfg.ShotClockLength,
+		time:       ts,
+		requests:   make(chan func()),
+		quit:       make(chan struct{}),
+		exited:     make(chan struct{}),
 	}
+	clock.stopClock = clock.wantStopClock()
+	clock.publish()
 
 	go clock.run()
 
@@ -0,0 +1,1002 @@
+package clock
+
+import (
+	"ScoreTableApi/internal/assert"
+	"testing"
+	"time"
+)
+
+// fakeTime is a TimeSource whose tickers only tick when a test advances it. Every ticker shares
+// one channel, as a GameClock runs at most one ticker at a time.
+type fakeTime struct {
+	ticks chan time.Time
+}
+
+func (f *fakeTime) NewTicker(time.Duration) Ticker {
+	return fakeTicker{c: f.ticks}
+}
+
+type fakeTicker struct {
+	c chan time.Time
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t fakeTicker) Stop() {}
+
+// advance delivers n ticks to the running ticker. Events are queued by the clock, so they can be
+// read after the ticks that caused them.
+func (f *fakeTime) advance(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case f.ticks <- time.Time{}:
+		case <-time.After(time.Second):
+			t.Fatalf("tick %d of %d was not received", i+1, n)
+		}
+	}
+}
+
+func newTestClock(cfg Config) (*GameClock, *fakeTime) {
+	ft := &fakeTime{ticks: make(chan time.Time)}
+	return NewGameClock(cfg, ft), ft
+}
+
+func send(t *testing.T, gc *GameClock, control Control) {
+	t.Helper()
+	select {
+	case gc.Controller <- control:
+	case <-time.After(time.Second):
+		t.Fatalf("control %d was not received", control)
+	}
+}
+
+func expectEvent(t *testing.T, gc *GameClock, eventType EventType, value string) {
+	t.Helper()
+	select {
+	case e := <-gc.C:
+		assert.Equal(t, e.EventType, eventType)
+		assert.Equal(t, e.Value, value)
+	case <-time.After(time.Second):
+		t.Fatalf("no event; want %d %q", eventType, value)
+	}
+}
+
+func expectNoEvent(t *testing.T, gc *GameClock) {
+	t.Helper()
+	select {
+	case e := <-gc.C:
+		t.Fatalf("got event %d %q; want none", e.EventType, e.Value)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+var timedConfig = Config{
+	PeriodLength:         3 * time.Second,
+	PeriodCount:          4,
+	OtDuration:           2 * time.Second,
+	TimeoutDuration:      2 * time.Second,
+	TimeoutsAllowed:      1,
+	ShortTimeoutDuration: time.Second,
+	ShortTimeoutsAllowed: 1,
+	TenthsThreshold:      -1,
+}
+
+func TestDurationToDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "Minutes And Seconds", value: "10:05", want: 10*time.Minute + 5*time.Second},
+		{name: "With Tenths", value: "01:05.3", want: time.Minute + 5300*time.Millisecond},
+		{name: "Seconds And Tenths", value: "59.9", want: 59900 * time.Millisecond},
+		{name: "Seconds Only", value: "59", wantErr: true},
+		{name: "Seconds Out Of Range", value: "01:60", wantErr: true},
+		{name: "Negative", value: "-01:00", wantErr: true},
+		{name: "Hundredths", value: "05.25", wantErr: true},
+		{name: "Not A Number", value: "ab:cd", wantErr: true},
+		{name: "Empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value.ToDuration()
+			assert.Equal(t, err != nil, tt.wantErr)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  time.Duration
+		tenths bool
+		want   string
+	}{
+		{name: "Whole Seconds", value: 12*time.Minute + 5*time.Second, want: "12:05"},
+		{name: "Rounds Up", value: 59100 * time.Millisecond, want: "01:00"},
+		{name: "Tenths", value: 5300 * time.Millisecond, tenths: true, want: "05.3"},
+		{name: "Negative", value: -time.Second, want: "00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, formatDuration(tt.value, tt.tenths), tt.want)
+		})
+	}
+}
+
+func TestFormatExact(t *testing.T) {
+	tests := []struct {
+		name  string
+		value time.Duration
+		want  Duration
+	}{
+		{name: "Whole Seconds", value: 12*time.Minute + 5*time.Second, want: "12:05.0"},
+		{name: "Tenths", value: 59100 * time.Millisecond, want: "00:59.1"},
+		{name: "Negative", value: -time.Second, want: "00:00.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatExact(tt.value)
+			assert.Equal(t, got, tt.want)
+			d, err := got.ToDuration()
+			assert.NilError(t, err)
+			assert.Equal(t, d, max(tt.value, 0))
+		})
+	}
+}
+
+func TestGetGameClock(t *testing.T) {
+	gc, _ := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, CallTimeoutHome)
+	expectEvent(t, gc, Timeout, "00:02")
+	assert.Equal(t, gc.Get(), "00:02")
+	assert.Equal(t, gc.GetGameClock(), "00:03")
+}
+
+func TestNewGameClock(t *testing.T) {
+	gc, _ := newTestClock(timedConfig)
+	defer gc.Close()
+
+	assert.Equal(t, gc.GetState(), StateFresh)
+	assert.Equal(t, gc.GetPeriod(), int64(1))
+	assert.Equal(t, gc.Get(), "00:03")
+	assert.Equal(t, gc.GetShotClock(), "")
+}
+
+func TestPlayPause(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+	assert.Equal(t, gc.GetState(), StatePlaying)
+
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+
+	send(t, gc, Play)
+	expectNoEvent(t, gc)
+
+	send(t, gc, Pause)
+	expectEvent(t, gc, Transport, "")
+	assert.Equal(t, gc.GetState(), StatePaused)
+	assert.Equal(t, gc.Get(), "00:02")
+
+	send(t, gc, Pause)
+	expectNoEvent(t, gc)
+}
+
+func TestPlayUntilDone(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:01")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Done, "")
+	assert.Equal(t, gc.GetState(), StateDone)
+	assert.Equal(t, gc.Remaining(), time.Duration(0))
+
+	send(t, gc, Play)
+	expectNoEvent(t, gc)
+}
+
+func TestTenths(t *testing.T) {
+	cfg := timedConfig
+	cfg.PeriodLength = time.Minute + time.Second
+	cfg.TenthsThreshold = 0
+	gc, ft := newTestClock(cfg)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "01:01")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "01:00")
+	ft.advance(t, 1)
+	expectEvent(t, gc, Tick, "59.9")
+	ft.advance(t, 1)
+	expectEvent(t, gc, Tick, "59.8")
+
+	send(t, gc, Pause)
+	expectEvent(t, gc, Transport, "")
+	assert.Equal(t, gc.Get(), "59.8")
+}
+
+func TestAdjust(t *testing.T) {
+	tests := []struct {
+		name    string
+		control Control
+		want    string
+	}{
+		{name: "Add Minute", control: AddMin, want: "01:03"},
+		{name: "Add Second", control: AddSec, want: "00:04"},
+		{name: "Subtract Second", control: SubtractSec, want: "00:02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc, _ := newTestClock(timedConfig)
+			defer gc.Close()
+
+			send(t, gc, tt.control)
+			expectEvent(t, gc, ClockSet, tt.want)
+			assert.Equal(t, gc.GetState(), StateFresh)
+		})
+	}
+
+	t.Run("Subtract Minute", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+		defer gc.Close()
+
+		send(t, gc, AddMin)
+		expectEvent(t, gc, ClockSet, "01:03")
+		send(t, gc, AddMin)
+		expectEvent(t, gc, ClockSet, "02:03")
+		send(t, gc, SubtractMin)
+		expectEvent(t, gc, ClockSet, "01:03")
+	})
+
+	t.Run("Ignored While Playing", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+		defer gc.Close()
+
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		send(t, gc, AddMin)
+		expectNoEvent(t, gc)
+		send(t, gc, Pause)
+		expectEvent(t, gc, Transport, "")
+	})
+
+	t.Run("Ignored During Timeout", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+		defer gc.Close()
+
+		send(t, gc, CallTimeoutHome)
+		expectEvent(t, gc, Timeout, "00:02")
+		for _, c := range []Control{AddMin, SubtractSec, Reset, AddPeriod} {
+			send(t, gc, c)
+		}
+		expectNoEvent(t, gc)
+		assert.Equal(t, gc.GetState(), StateTimeout)
+		assert.Equal(t, gc.GetPeriod(), int64(1))
+		assert.Equal(t, gc.Remaining(), 3*time.Second)
+	})
+}
+
+func TestReset(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+
+	send(t, gc, Reset)
+	expectNoEvent(t, gc)
+
+	send(t, gc, Pause)
+	expectEvent(t, gc, Transport, "")
+	send(t, gc, Reset)
+	expectEvent(t, gc, ClockSet, "00:03")
+	assert.Equal(t, gc.GetState(), StateFresh)
+}
+
+func TestChangePeriod(t *testing.T) {
+	gc, _ := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, SubtractPeriod)
+	expectNoEvent(t, gc)
+
+	for _, want := range []string{"2/4", "3/4", "4/4"} {
+		send(t, gc, AddPeriod)
+		expectEvent(t, gc, PeriodSet, want)
+		expectEvent(t, gc, ClockSet, "00:03")
+	}
+
+	// Overtime periods start at OtDuration.
+	send(t, gc, AddPeriod)
+	expectEvent(t, gc, PeriodSet, "5/4")
+	expectEvent(t, gc, ClockSet, "00:02")
+	assert.Equal(t, gc.GetPeriod(), int64(5))
+
+	send(t, gc, SubtractPeriod)
+	expectEvent(t, gc, PeriodSet, "4/4")
+	expectEvent(t, gc, ClockSet, "00:03")
+
+	t.Run("Done", func(t *testing.T) {
+		gc, ft := newTestClock(timedConfig)
+		defer gc.Close()
+
+		err := gc.SetPeriod(4)
+		assert.NilError(t, err)
+		expectEvent(t, gc, PeriodSet, "4/4")
+		expectEvent(t, gc, ClockSet, "00:03")
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		ft.advance(t, 30)
+		expectEvent(t, gc, Tick, "00:02")
+		expectEvent(t, gc, Tick, "00:01")
+		expectEvent(t, gc, Done, "")
+
+		// A finished period moves into overtime ready to play.
+		send(t, gc, AddPeriod)
+		expectEvent(t, gc, PeriodSet, "5/4")
+		expectEvent(t, gc, ClockSet, "00:02")
+		assert.Equal(t, gc.GetState(), StateFresh)
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:02")
+	})
+
+	t.Run("Ignored While Paused", func(t *testing.T) {
+		gc, ft := newTestClock(timedConfig)
+		defer gc.Close()
+
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		ft.advance(t, 10)
+		expectEvent(t, gc, Tick, "00:02")
+		send(t, gc, Pause)
+		expectEvent(t, gc, Transport, "")
+
+		send(t, gc, AddPeriod)
+		expectNoEvent(t, gc)
+		assert.Equal(t, gc.GetPeriod(), int64(1))
+	})
+}
+
+func TestSet(t *testing.T) {
+	gc, _ := newTestClock(timedConfig)
+	defer gc.Close()
+
+	err := gc.Set("01:30.5")
+	assert.NilError(t, err)
+	expectEvent(t, gc, ClockSet, "01:31")
+	assert.Equal(t, gc.Remaining(), 90500*time.Millisecond)
+	assert.Equal(t, gc.GetState(), StateFresh)
+
+	err = gc.Set("1:5")
+	assert.NilError(t, err)
+	expectEvent(t, gc, ClockSet, "01:05")
+
+	err = gc.Set("00:00")
+	assert.NilError(t, err)
+	expectEvent(t, gc, ClockSet, "00:00")
+	assert.Equal(t, gc.GetState(), StateDone)
+
+	err = gc.Set("1:60")
+	assert.Equal(t, err, ErrInvalidDuration)
+	expectNoEvent(t, gc)
+
+	t.Run("Rejected While Running", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+		defer gc.Close()
+
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		err := gc.Set("00:01")
+		assert.Equal(t, err, ErrClockRunning)
+		send(t, gc, Pause)
+		expectEvent(t, gc, Transport, "")
+
+		send(t, gc, CallTimeoutHome)
+		expectEvent(t, gc, Timeout, "00:02")
+		err = gc.Set("00:01")
+		assert.Equal(t, err, ErrClockRunning)
+		expectNoEvent(t, gc)
+	})
+}
+
+func TestSetPeriod(t *testing.T) {
+	cfg := timedConfig
+	cfg.ShotClockLength = 2 * time.Second
+	gc, ft := newTestClock(cfg)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+	expectEvent(t, gc, ShotClockTick, "1")
+	send(t, gc, Pause)
+	expectEvent(t, gc, Transport, "")
+
+	// Unlike AddPeriod, a paused clock can jump to a period.
+	err := gc.SetPeriod(3)
+	assert.NilError(t, err)
+	expectEvent(t, gc, PeriodSet, "3/4")
+	expectEvent(t, gc, ClockSet, "00:03")
+	assert.Equal(t, gc.GetPeriod(), int64(3))
+	assert.Equal(t, gc.GetShotClock(), "2")
+	assert.Equal(t, gc.GetState(), StateFresh)
+
+	err = gc.SetPeriod(6)
+	assert.NilError(t, err)
+	expectEvent(t, gc, PeriodSet, "6/4")
+	expectEvent(t, gc, ClockSet, "00:02")
+
+	err = gc.SetPeriod(0)
+	assert.Equal(t, err, ErrInvalidPeriod)
+	expectNoEvent(t, gc)
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:02")
+	err = gc.SetPeriod(1)
+	assert.Equal(t, err, ErrClockRunning)
+}
+
+func TestApply(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	pos, err := gc.Apply(Play, "")
+	assert.NilError(t, err)
+	assert.Equal(t, pos, Position{Period: 1, Remaining: 3 * time.Second, State: StateFresh})
+	expectEvent(t, gc, Transport, "00:03")
+
+	ft.advance(t, 5)
+	_, err = gc.Apply(Play, "")
+	assert.Equal(t, err, ErrControlIgnored)
+	_, err = gc.Apply(AddMin, "")
+	assert.Equal(t, err, ErrClockRunning)
+
+	// The position is read before the control is applied.
+	pos, err = gc.Apply(Pause, "")
+	assert.NilError(t, err)
+	assert.Equal(t, pos, Position{Period: 1, Remaining: 2500 * time.Millisecond,
+		State: StatePlaying})
+	expectEvent(t, gc, Transport, "")
+	_, err = gc.Apply(AddPeriod, "")
+	assert.Equal(t, err, ErrPeriodStarted)
+
+	pos, err = gc.Apply(SetClock, "00:01")
+	assert.NilError(t, err)
+	assert.Equal(t, pos, Position{Period: 1, Remaining: 2500 * time.Millisecond,
+		State: StatePaused})
+	expectEvent(t, gc, ClockSet, "00:01")
+
+	_, err = gc.Apply(SetPeriod, "two")
+	assert.Equal(t, err, ErrInvalidPeriod)
+	_, err = gc.Apply(ResetShotClock, "")
+	assert.Equal(t, err, ErrControlIgnored)
+
+	_, err = gc.Apply(CallTimeoutHome, "")
+	assert.NilError(t, err)
+	expectEvent(t, gc, Timeout, "00:02")
+	_, err = gc.Apply(EndTimeout, "")
+	assert.NilError(t, err)
+	expectEvent(t, gc, TimeoutDone, "00:01")
+	_, err = gc.Apply(CallTimeoutHome, "")
+	assert.Equal(t, err, ErrNoTimeoutsLeft)
+	expectNoEvent(t, gc)
+
+	gc.Close()
+	_, err = gc.Apply(Reset, "")
+	assert.Equal(t, err, ErrClockRunning)
+}
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		control Control
+		value   string
+		used    string
+	}{
+		{name: "Home", control: CallTimeoutHome, value: "00:02", used: "home"},
+		{name: "Away", control: CallTimeoutAway, value: "00:02", used: "away"},
+		{name: "Short Home", control: CallShortTimeoutHome, value: "00:01", used: "home_short"},
+		{name: "Short Away", control: CallShortTimeoutAway, value: "00:01", used: "away_short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc, _ := newTestClock(timedConfig)
+			defer gc.Close()
+
+			send(t, gc, tt.control)
+			expectEvent(t, gc, Timeout, tt.value)
+			assert.Equal(t, gc.GetState(), StateTimeout)
+			assert.Equal(t, gc.GetTimeouts()[tt.used], 1)
+
+			send(t, gc, EndTimeout)
+			expectEvent(t, gc, TimeoutDone, "00:03")
+			assert.Equal(t, gc.GetState(), StatePaused)
+
+			// Each kind of timeout is allowed once.
+			send(t, gc, tt.control)
+			expectNoEvent(t, gc)
+		})
+	}
+}
+
+func TestTimeoutExpires(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, CallTimeoutHome)
+	expectEvent(t, gc, Timeout, "00:02")
+	ft.advance(t, 1)
+	expectEvent(t, gc, Tick, "00:01")
+	ft.advance(t, 1)
+	expectEvent(t, gc, TimeoutDone, "00:03")
+	assert.Equal(t, gc.GetState(), StatePaused)
+}
+
+func TestTimeoutWhilePlaying(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+
+	send(t, gc, CallTimeoutAway)
+	expectEvent(t, gc, Timeout, "00:02")
+	assert.Equal(t, gc.Remaining(), 2*time.Second)
+
+	send(t, gc, EndTimeout)
+	expectEvent(t, gc, TimeoutDone, "00:02")
+}
+
+func TestTimeoutsPerHalf(t *testing.T) {
+	cfg := timedConfig
+	cfg.TimeoutsPerHalf = true
+	gc, _ := newTestClock(cfg)
+	defer gc.Close()
+
+	send(t, gc, CallTimeoutHome)
+	expectEvent(t, gc, Timeout, "00:02")
+	send(t, gc, EndTimeout)
+	expectEvent(t, gc, TimeoutDone, "00:03")
+
+	send(t, gc, Reset)
+	expectEvent(t, gc, ClockSet, "00:03")
+	send(t, gc, AddPeriod)
+	expectEvent(t, gc, PeriodSet, "2/4")
+	expectEvent(t, gc, ClockSet, "00:03")
+	send(t, gc, CallTimeoutHome)
+	expectNoEvent(t, gc)
+
+	send(t, gc, AddPeriod)
+	expectEvent(t, gc, PeriodSet, "3/4")
+	expectEvent(t, gc, ClockSet, "00:03")
+	assert.Equal(t, gc.GetTimeouts()["home"], 0)
+	send(t, gc, CallTimeoutHome)
+	expectEvent(t, gc, Timeout, "00:02")
+	send(t, gc, EndTimeout)
+	expectEvent(t, gc, TimeoutDone, "00:03")
+}
+
+func TestShotClock(t *testing.T) {
+	cfg := timedConfig
+	cfg.PeriodLength = time.Minute
+	cfg.ShotClockLength = 2 * time.Second
+	cfg.ShotClockShort = time.Second
+	gc, ft := newTestClock(cfg)
+	defer gc.Close()
+
+	assert.Equal(t, gc.GetShotClock(), "2")
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "01:00")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:59")
+	expectEvent(t, gc, ShotClockTick, "1")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:58")
+	expectEvent(t, gc, ShotClockViolation, "0")
+	expectEvent(t, gc, Transport, "")
+	assert.Equal(t, gc.GetState(), StatePaused)
+
+	send(t, gc, ResetShotClockShort)
+	expectEvent(t, gc, ShotClockSet, "1")
+	send(t, gc, ResetShotClock)
+	expectEvent(t, gc, ShotClockSet, "2")
+
+	t.Run("Off", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+		defer gc.Close()
+
+		send(t, gc, ResetShotClock)
+		expectNoEvent(t, gc)
+	})
+}
+
+func TestBreak(t *testing.T) {
+	cfg := timedConfig
+	cfg.BreakDuration = 2 * time.Second
+	cfg.HalftimeDuration = 3 * time.Second
+	cfg.OtBreakDuration = time.Second
+
+	tests := []struct {
+		name   string
+		period int64
+		ticks  []string
+		next   string
+		clock  string
+	}{
+		{name: "Between Periods", period: 1, ticks: []string{"00:02", "00:01"}, next: "2/4",
+			clock: "00:03"},
+		{name: "Halftime", period: 2, ticks: []string{"00:03", "00:02", "00:01"}, next: "3/4",
+			clock: "00:03"},
+		{name: "Before Overtime", period: 4, ticks: []string{"00:01"}, next: "5/4",
+			clock: "00:02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc, ft := newTestClock(cfg)
+			defer gc.Close()
+
+			err := gc.Restore(Position{Period: tt.period, State: StateDone})
+			assert.NilError(t, err)
+
+			send(t, gc, StartBreak)
+			expectEvent(t, gc, BreakStart, tt.ticks[0])
+			assert.Equal(t, gc.GetState(), StateBreak)
+			assert.Equal(t, gc.GetBreak(), tt.ticks[0])
+
+			for _, value := range tt.ticks[1:] {
+				ft.advance(t, 1)
+				expectEvent(t, gc, BreakTick, value)
+			}
+			ft.advance(t, 1)
+			expectEvent(t, gc, BreakDone, "")
+			expectEvent(t, gc, PeriodSet, tt.next)
+			expectEvent(t, gc, ClockSet, tt.clock)
+			assert.Equal(t, gc.GetState(), StateFresh)
+			assert.Equal(t, gc.GetBreak(), "")
+		})
+	}
+
+	t.Run("Started When Period Runs Out", func(t *testing.T) {
+		gc, ft := newTestClock(cfg)
+		defer gc.Close()
+
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		ft.advance(t, 30)
+		expectEvent(t, gc, Tick, "00:02")
+		expectEvent(t, gc, Tick, "00:01")
+		expectEvent(t, gc, Done, "")
+		expectEvent(t, gc, BreakStart, "00:02")
+		assert.Equal(t, gc.GetState(), StateBreak)
+
+		// Overtime depends on the score, so its break is only started by StartBreak.
+		send(t, gc, EndBreak)
+		expectEvent(t, gc, BreakDone, "")
+		expectEvent(t, gc, PeriodSet, "2/4")
+		expectEvent(t, gc, ClockSet, "00:03")
+		err := gc.SetPeriod(4)
+		assert.NilError(t, err)
+		expectEvent(t, gc, PeriodSet, "4/4")
+		expectEvent(t, gc, ClockSet, "00:03")
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		ft.advance(t, 30)
+		expectEvent(t, gc, Tick, "00:02")
+		expectEvent(t, gc, Tick, "00:01")
+		expectEvent(t, gc, Done, "")
+		expectNoEvent(t, gc)
+		assert.Equal(t, gc.GetState(), StateDone)
+	})
+
+	t.Run("Ended Early", func(t *testing.T) {
+		gc, _ := newTestClock(cfg)
+		defer gc.Close()
+
+		err := gc.Restore(Position{Period: 1, State: StateDone})
+		assert.NilError(t, err)
+		send(t, gc, StartBreak)
+		expectEvent(t, gc, BreakStart, "00:02")
+
+		// The clock cannot be changed during a break.
+		send(t, gc, Play)
+		send(t, gc, CallTimeoutHome)
+		send(t, gc, AddPeriod)
+		err = gc.Set("00:01")
+		assert.Equal(t, err, ErrClockRunning)
+		expectNoEvent(t, gc)
+
+		send(t, gc, EndBreak)
+		expectEvent(t, gc, BreakDone, "")
+		expectEvent(t, gc, PeriodSet, "2/4")
+		expectEvent(t, gc, ClockSet, "00:03")
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		gc, _ := newTestClock(cfg)
+		defer gc.Close()
+
+		// The period has not run out.
+		send(t, gc, StartBreak)
+		expectNoEvent(t, gc)
+
+		// The game has no break between periods.
+		gc, _ = newTestClock(timedConfig)
+		defer gc.Close()
+		err := gc.Restore(Position{Period: 1, State: StateDone})
+		assert.NilError(t, err)
+		send(t, gc, StartBreak)
+		expectNoEvent(t, gc)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		gc, _ := newTestClock(cfg)
+		defer gc.Close()
+
+		err := gc.Restore(Position{Period: 2, State: StateDone})
+		assert.NilError(t, err)
+		gc.Replay(StartBreak, "")
+		assert.Equal(t, gc.GetPeriod(), int64(3))
+		assert.Equal(t, gc.GetState(), StateFresh)
+		expectNoEvent(t, gc)
+	})
+}
+
+func TestRunningClock(t *testing.T) {
+	cfg := timedConfig
+	cfg.PeriodCount = 2
+	cfg.ShotClockLength = time.Second
+	cfg.RunningClock = true
+	cfg.StopClockUnder = 2 * time.Second
+	gc, ft := newTestClock(cfg)
+	defer gc.Close()
+
+	assert.Equal(t, gc.GetMode(), ModeRunning)
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+
+	// Neither a pause nor a shot clock violation stops a running clock.
+	_, err := gc.Apply(Pause, "")
+	assert.Equal(t, err, ErrRunningClock)
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+	expectEvent(t, gc, ShotClockViolation, "0")
+	assert.Equal(t, gc.GetState(), StatePlaying)
+
+	// The threshold only applies in the last period.
+	ft.advance(t, 20)
+	expectEvent(t, gc, Tick, "00:01")
+	expectEvent(t, gc, Done, "")
+	err = gc.SetPeriod(2)
+	assert.NilError(t, err)
+	expectEvent(t, gc, PeriodSet, "2/2")
+	expectEvent(t, gc, ClockSet, "00:03")
+
+	send(t, gc, Play)
+	expectEvent(t, gc, Transport, "00:03")
+	ft.advance(t, 10)
+	expectEvent(t, gc, Tick, "00:02")
+	expectEvent(t, gc, ShotClockViolation, "0")
+	ft.advance(t, 1)
+	expectEvent(t, gc, ModeChange, ModeStop)
+	assert.Equal(t, gc.GetMode(), ModeStop)
+
+	send(t, gc, Pause)
+	expectEvent(t, gc, Transport, "")
+	assert.Equal(t, gc.GetState(), StatePaused)
+
+	t.Run("Margin", func(t *testing.T) {
+		cfg := timedConfig
+		cfg.RunningClock = true
+		cfg.StopClockMargin = 10
+		gc, ft := newTestClock(cfg)
+		defer gc.Close()
+
+		assert.Equal(t, gc.GetMode(), ModeStop)
+		gc.SetMargin(-12)
+		assert.Equal(t, gc.GetMode(), ModeRunning)
+
+		send(t, gc, Play)
+		expectEvent(t, gc, Transport, "00:03")
+		expectEvent(t, gc, ModeChange, ModeRunning)
+		send(t, gc, Pause)
+		expectNoEvent(t, gc)
+
+		gc.SetMargin(9)
+		ft.advance(t, 1)
+		expectEvent(t, gc, ModeChange, ModeStop)
+		send(t, gc, Pause)
+		expectEvent(t, gc, Transport, "")
+	})
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  Position
+		want State
+	}{
+		{name: "Fresh", pos: Position{Period: 2, Remaining: 3 * time.Second, State: StateFresh},
+			want: StateFresh},
+		{name: "Fresh Overtime", pos: Position{Period: 5, Remaining: 2 * time.Second,
+			State: StateFresh}, want: StateFresh},
+		{name: "Adjusted", pos: Position{Period: 2, Remaining: time.Second, State: StateFresh},
+			want: StateFresh},
+		{name: "Paused", pos: Position{Period: 3, Remaining: 1500 * time.Millisecond,
+			State: StatePaused}, want: StatePaused},
+		{name: "Playing", pos: Position{Period: 3, Remaining: 1500 * time.Millisecond,
+			State: StatePlaying}, want: StatePaused},
+		{name: "Timeout", pos: Position{Period: 1, Remaining: 3 * time.Second,
+			State: StateTimeout}, want: StatePaused},
+		{name: "Done", pos: Position{Period: 4, State: StateDone}, want: StateDone},
+		{name: "Break", pos: Position{Period: 1, State: StateBreak}, want: StateDone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc, _ := newTestClock(timedConfig)
+			defer gc.Close()
+
+			err := gc.Restore(tt.pos)
+			assert.NilError(t, err)
+			assert.Equal(t, gc.GetState(), tt.want)
+			assert.Equal(t, gc.GetPeriod(), tt.pos.Period)
+			assert.Equal(t, gc.Remaining(), tt.pos.Remaining)
+			expectNoEvent(t, gc)
+		})
+	}
+
+	t.Run("Adjusted Then Add Period", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+		defer gc.Close()
+
+		// A clock adjusted before its period started can still move to the next period.
+		err := gc.Restore(Position{Period: 1, Remaining: 2 * time.Second, State: StateFresh})
+		assert.NilError(t, err)
+		gc.Replay(AddPeriod, "")
+		assert.Equal(t, gc.GetPeriod(), int64(2))
+		assert.Equal(t, gc.Get(), "00:03")
+	})
+}
+
+func TestReplay(t *testing.T) {
+	gc, _ := newTestClock(timedConfig)
+	defer gc.Close()
+
+	for _, c := range []Control{Play, AddMin, SubtractSec, CallTimeoutHome, CallShortTimeoutAway} {
+		gc.Replay(c, "")
+	}
+	assert.Equal(t, gc.GetState(), StateFresh)
+	assert.Equal(t, gc.Get(), "01:02")
+	assert.Equal(t, gc.GetTimeouts()["home"], 1)
+	assert.Equal(t, gc.GetTimeouts()["away_short"], 1)
+
+	gc.Replay(Reset, "")
+	gc.Replay(AddPeriod, "")
+	assert.Equal(t, gc.GetPeriod(), int64(2))
+
+	gc.Replay(SetClock, "00:10")
+	gc.Replay(SetPeriod, "4")
+	assert.Equal(t, gc.GetPeriod(), int64(4))
+	assert.Equal(t, gc.Get(), "00:03")
+	expectNoEvent(t, gc)
+}
+
+func TestClose(t *testing.T) {
+	gc, _ := newTestClock(timedConfig)
+	gc.Close()
+
+	assert.Equal(t, gc.GetState(), StateClosed)
+	assert.Equal(t, gc.Get(), "")
+	_, ok := <-gc.C
+	assert.Equal(t, ok, false)
+
+	err := gc.Restore(Position{Period: 1, Remaining: 3 * time.Second})
+	assert.Equal(t, err, ErrClockRunning)
+	gc.Close()
+
+	t.Run("While Playing", func(t *testing.T) {
+		gc, ft := newTestClock(timedConfig)
+
+		send(t, gc, Play)
+		ft.advance(t, 10)
+		gc.Close()
+
+		assert.Equal(t, gc.GetState(), StateClosed)
+		_, ok := <-gc.C
+		assert.Equal(t, ok, false)
+	})
+
+	t.Run("During Timeout", func(t *testing.T) {
+		gc, _ := newTestClock(timedConfig)
+
+		send(t, gc, CallTimeoutHome)
+		gc.Close()
+
+		assert.Equal(t, gc.GetState(), StateClosed)
+	})
+}
+
+func TestEventsQueued(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	// Controls are received while events wait to be read.
+	send(t, gc, Play)
+	ft.advance(t, 15)
+	send(t, gc, Pause)
+	send(t, gc, AddSec)
+
+	expectEvent(t, gc, Transport, "00:03")
+	expectEvent(t, gc, Tick, "00:02")
+	expectEvent(t, gc, Transport, "")
+	expectEvent(t, gc, ClockSet, "00:03")
+	expectNoEvent(t, gc)
+}
+
+func TestEventRemaining(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	send(t, gc, Play)
+	ft.advance(t, 5)
+	send(t, gc, Pause)
+
+	e := <-gc.C
+	assert.Equal(t, e.Remaining, 3*time.Second)
+	e = <-gc.C
+	assert.Equal(t, e.EventType, Transport)
+	assert.Equal(t, e.Remaining, 2500*time.Millisecond)
+}
+
+func TestConcurrentReads(t *testing.T) {
+	gc, ft := newTestClock(timedConfig)
+	defer gc.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			gc.Get()
+			gc.GetPeriod()
+			gc.GetTimeouts()
+			gc.GetState()
+			gc.Remaining()
+		}
+	}()
+
+	send(t, gc, Play)
+	ft.advance(t, 10)
+	send(t, gc, CallTimeoutHome)
+	<-done
+}
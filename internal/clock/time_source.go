@@ -0,0 +1,32 @@
+package clock
+
+import "time"
+
+// TimeSource creates the tickers a GameClock counts down with, so that tests can drive a GameClock
+// without waiting on real time.
+type TimeSource interface {
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks on C until it is stopped, like a time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemTime is the TimeSource backed by the time package.
+var SystemTime TimeSource = systemTime{}
+
+type systemTime struct{}
+
+func (systemTime) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
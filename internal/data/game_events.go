@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	json2 "encoding/json"
+	"time"
+)
+
+// GameEvent is a single executed keeper event in the append-only log of a live game.
+type GameEvent struct {
+	ID         int64            `json:"-"`
+	GameID     int64            `json:"-"`
+	Seq        int64            `json:"seq"`
+	UserID     int64            `json:"-"`
+	Period     int64            `json:"period"`
+	Clock      string           `json:"clock"`
+	ClockState int64            `json:"clock_state"`
+	Type       int64            `json:"type"`
+	Payload    json2.RawMessage `json:"payload"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type GameEventModel struct {
+	db *sql.DB
+}
+
+func (m *GameEventModel) Insert(event *GameEvent) error {
+	stmt := `
+		INSERT INTO game_events (game_id, seq, user_id, period, clock, clock_state, type, payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	args := []any{
+		event.GameID,
+		event.Seq,
+		event.UserID,
+		event.Period,
+		event.Clock,
+		event.ClockState,
+		event.Type,
+		[]byte(event.Payload),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.db.QueryRowContext(ctx, stmt, args...).Scan(&event.ID, &event.CreatedAt)
+}
+
+// GetAllForGame returns the event log of a game in sequence order.
+func (m *GameEventModel) GetAllForGame(gameID int64) ([]*GameEvent, error) {
+	stmt := `
+		SELECT id, game_id, seq, user_id, period, clock, clock_state, type, payload, created_at
+		FROM game_events
+		WHERE game_id = $1
+		ORDER BY seq`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, stmt, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*GameEvent, 0)
+	for rows.Next() {
+		var event GameEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.GameID,
+			&event.Seq,
+			&event.UserID,
+			&event.Period,
+			&event.Clock,
+			&event.ClockState,
+			&event.Type,
+			(*[]byte)(&event.Payload),
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
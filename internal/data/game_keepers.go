@@ -0,0 +1,130 @@
+package data
+
+import (
+	"ScoreTableApi/internal/validator"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrDuplicateKeeper = errors.New("user is already a keeper for game")
+
+// KeeperRole restricts which parts of a live game a keeper can control.
+type KeeperRole string
+
+const (
+	KeeperRoleAll          KeeperRole = "all"
+	KeeperRoleStats        KeeperRole = "stat"
+	KeeperRoleClock        KeeperRole = "clock"
+	KeeperRoleSubstitution KeeperRole = "substitution"
+)
+
+var KeeperRoles = []KeeperRole{KeeperRoleAll, KeeperRoleStats, KeeperRoleClock,
+	KeeperRoleSubstitution}
+
+func ValidateKeeperRole(v *validator.Validator, role KeeperRole) {
+	v.Check(validator.PermittedValue(role, KeeperRoles...), "role",
+		fmt.Sprintf(`must be one of the following: "%s", "%s", "%s", "%s"`, KeeperRoleAll,
+			KeeperRoleStats, KeeperRoleClock, KeeperRoleSubstitution))
+}
+
+// GameKeeper is a user invited to keep a game, and the role they keep it with.
+type GameKeeper struct {
+	*User
+	Role KeeperRole `json:"role"`
+}
+
+// GameKeeperModel manages the users, other than a game's owner, that are allowed to keep a game.
+type GameKeeperModel struct {
+	db *sql.DB
+}
+
+func (m *GameKeeperModel) Insert(gameID, userID int64, role KeeperRole) error {
+	stmt := `
+		INSERT INTO game_keepers (game_id, user_id, role)
+		VALUES ($1, $2, $3)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.db.ExecContext(ctx, stmt, gameID, userID, role)
+	if err != nil {
+		switch {
+		case err.Error() == `pq: duplicate key value violates unique constraint "game_keepers_pkey"`:
+			return ErrDuplicateKeeper
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *GameKeeperModel) Delete(gameID, userID int64) error {
+	stmt := `
+		DELETE FROM game_keepers
+		WHERE game_id = $1 AND user_id = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.db.ExecContext(ctx, stmt, gameID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetAllForGame returns the users invited to keep a game.
+func (m *GameKeeperModel) GetAllForGame(gameID int64) ([]*GameKeeper, error) {
+	stmt := `
+		SELECT users.id, users.created_at, users.first_name, users.last_name, users.email, 
+			users.activated, game_keepers.role
+		FROM game_keepers
+		JOIN users ON game_keepers.user_id = users.id
+		WHERE game_keepers.game_id = $1
+		ORDER BY game_keepers.created_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, stmt, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keepers := make([]*GameKeeper, 0)
+	for rows.Next() {
+		keeper := GameKeeper{User: &User{}}
+		err := rows.Scan(
+			&keeper.ID,
+			&keeper.CreatedAt,
+			&keeper.FirstName,
+			&keeper.LastName,
+			&keeper.Email,
+			&keeper.Activated,
+			&keeper.Role,
+		)
+		if err != nil {
+			return nil, err
+		}
+		keepers = append(keepers, &keeper)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keepers, nil
+}
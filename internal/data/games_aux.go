@@ -317,3 +317,56 @@ func checkTeamConflict(game *Game, tx *sql.Tx, ctx context.Context) error {
 
 	return nil
 }
+
+// gameRulesColumns are the columns of games holding the rules of a game that are not part of
+// games_view, in the order gameRulesDest scans them.
+const gameRulesColumns = `games.win_by_two, games.bonus_fouls, games.double_bonus_fouls,
+	games.foul_limit, games.timeouts_per, games.timeouts, games.short_timeouts,
+	games.timeout_length, games.short_timeout_length, games.ot_length, games.overtimes_before_tie,
+	games.shot_clock_length, games.shot_clock_short, games.break_length, games.halftime_length,
+	games.ot_break_length, games.running_clock, games.stop_clock_under, games.stop_clock_margin`
+
+// gameRulesDest returns the fields of game that gameRulesColumns are scanned into.
+func gameRulesDest(game *Game) []any {
+	return []any{
+		&game.WinByTwo,
+		&game.BonusFouls,
+		&game.DoubleBonusFouls,
+		&game.FoulLimit,
+		&game.TimeoutsPer,
+		&game.Timeouts,
+		&game.ShortTimeouts,
+		&game.TimeoutLength,
+		&game.ShortTimeoutLength,
+		&game.OtLength,
+		&game.OvertimesBeforeTie,
+		&game.ShotClockLength,
+		&game.ShotClockShort,
+		&game.BreakLength,
+		&game.HalftimeLength,
+		&game.OtBreakLength,
+		&game.RunningClock,
+		&game.StopClockUnder,
+		&game.StopClockMargin,
+	}
+}
+
+// getGameRules loads the rules of a game that are not part of games_view.
+func getGameRules(game *Game, tx *sql.Tx, ctx context.Context) error {
+	stmt := `
+		SELECT ` + gameRulesColumns + `
+		FROM games
+		WHERE id = $1`
+
+	err := tx.QueryRowContext(ctx, stmt, game.ID).Scan(gameRulesDest(game)...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,16 @@ import (
 )
 
 func (m *GameModel) Get(userID int64, pin string) (*Game, error) {
+	return m.get("user_id = $1 AND pin = $2", userID, pin)
+}
+
+// GetByPin returns a game by its pin regardless of which user owns it. It is used for public views
+// of a game, such as its play-by-play.
+func (m *GameModel) GetByPin(pin string) (*Game, error) {
+	return m.get("pin = $1", pin)
+}
+
+func (m *GameModel) get(where string, args ...any) (*Game, error) {
 	stmt := `
 		SELECT games_view.pin_id, games_view.pin, games_view.scope, games_view.id, 
 			games_view.user_id, games_view.created_at, games_view.version, games_view.status, 
@@ -16,7 +26,7 @@ func (m *GameModel) Get(userID int64, pin string) (*Game, error) {
 			games_view.period_count, games_view.score_target, games_view.home_team_pin, 
 			games_view.away_team_pin, games_view.home_player_pins, games_view.away_player_pins
 			FROM games_view
-			WHERE user_id = $1 AND pin = $2`
+			WHERE ` + where
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -27,7 +37,7 @@ func (m *GameModel) Get(userID int64, pin string) (*Game, error) {
 	}
 
 	game := &Game{}
-	err = tx.QueryRowContext(ctx, stmt, userID, pin).Scan(
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(
 		&game.PinID.ID,
 		&game.PinID.Pin,
 		&game.PinID.Scope,
@@ -59,6 +69,14 @@ func (m *GameModel) Get(userID int64, pin string) (*Game, error) {
 		}
 	}
 
+	err = getGameRules(game, tx, ctx)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, rollbackErr
+		}
+		return nil, err
+	}
+
 	err = getGameTeams(game, tx, ctx)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
@@ -85,3 +103,100 @@ func (m *GameModel) Get(userID int64, pin string) (*Game, error) {
 
 	return game, nil
 }
+
+// GetAllInProgress returns every game with an INPROGRESS status, across all users, with its teams
+// and players loaded.
+func (m *GameModel) GetAllInProgress() ([]*Game, error) {
+	stmt := `
+		SELECT games_view.pin_id, games_view.pin, games_view.scope, games_view.id, 
+			games_view.user_id, games_view.created_at, games_view.version, games_view.status, 
+			games_view.date_time, games_view.team_size, games_view.type, games_view.period_length, 
+			games_view.period_count, games_view.score_target, games_view.home_team_pin, 
+			games_view.away_team_pin, games_view.home_player_pins, games_view.away_player_pins
+			FROM games_view
+			WHERE status = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, stmt, INPROGRESS)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, rollbackErr
+		}
+		return nil, err
+	}
+
+	games := make([]*Game, 0)
+	for rows.Next() {
+		var game Game
+		err := rows.Scan(
+			&game.PinID.ID,
+			&game.PinID.Pin,
+			&game.PinID.Scope,
+			&game.ID,
+			&game.UserID,
+			&game.CreatedAt,
+			&game.Version,
+			&game.Status,
+			&game.DateTime,
+			&game.TeamSize,
+			&game.Type,
+			&game.PeriodLength,
+			&game.PeriodCount,
+			&game.ScoreTarget,
+			&game.HomeTeamPin,
+			&game.AwayTeamPin,
+			pq.Array(&game.HomePlayerPins),
+			pq.Array(&game.AwayPlayerPins),
+		)
+		if err != nil {
+			rows.Close()
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return nil, rollbackErr
+			}
+			return nil, err
+		}
+		games = append(games, &game)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, rollbackErr
+		}
+		return nil, err
+	}
+
+	for _, g := range games {
+		err = getGameRules(g, tx, ctx)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return nil, rollbackErr
+			}
+			return nil, err
+		}
+
+		err = getGameTeamsPlayers(g, tx, ctx)
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return nil, rollbackErr
+			}
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, rollbackErr
+		}
+		return nil, err
+	}
+
+	return games, nil
+}
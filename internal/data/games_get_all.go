@@ -43,9 +43,13 @@ type GamesMetadata struct {
 func (m *GameModel) GetAll(userID int64, filters GamesFilter, includes []string) ([]*Game,
 	GamesMetadata, error) {
 	stmt := fmt.Sprintf(`
-		SELECT count(*) OVER(), pin_id, pin, scope, id, user_id, created_at, version, status, date_time, 
-			team_size, period_length, period_count, score_target, type
+		SELECT count(*) OVER(), games_view.pin_id, games_view.pin, games_view.scope, 
+			games_view.id, games_view.user_id, games_view.created_at, games_view.version, 
+			games_view.status, games_view.date_time, games_view.team_size, 
+			games_view.period_length, games_view.period_count, games_view.score_target, 
+			games_view.type, %s
 			FROM games_view
+				JOIN games ON games.id = games_view.id
 			WHERE games_view.user_id = $1
 			AND (($2 IS FALSE)
 				OR games_view.home_team_pin = ANY($3) 
@@ -62,8 +66,9 @@ func (m *GameModel) GetAll(userID int64, filters GamesFilter, includes []string)
 				OR games_view.team_size = ANY($13::integer[]))
 			AND (($14 IS FALSE)
 				OR games_view.status = ANY($15::integer[]))
-			ORDER BY %s %s, id ASC
-			LIMIT $16 OFFSET $17`, filters.Filters.sortColumn(), filters.Filters.sortDirection())
+			ORDER BY games_view.%s %s, games_view.id ASC
+			LIMIT $16 OFFSET $17`, gameRulesColumns, filters.Filters.sortColumn(),
+		filters.Filters.sortDirection())
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -104,7 +109,7 @@ func (m *GameModel) GetAll(userID int64, filters GamesFilter, includes []string)
 	games := make([]*Game, 0)
 	for rows.Next() {
 		var game Game
-		err := rows.Scan(
+		dest := []any{
 			&totalRecords,
 			&game.PinID.ID,
 			&game.PinID.Pin,
@@ -120,7 +125,8 @@ func (m *GameModel) GetAll(userID int64, filters GamesFilter, includes []string)
 			&game.PeriodCount,
 			&game.ScoreTarget,
 			&game.Type,
-		)
+		}
+		err := rows.Scan(append(dest, gameRulesDest(&game)...)...)
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
 				return nil, GamesMetadata{}, rollbackErr
@@ -29,8 +29,12 @@ func (m *GameModel) Insert(game *Game) error {
 
 	stmt := `
 		INSERT INTO games (user_id, pin_id, date_time, team_size, 
-			period_length, period_count, score_target)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+			period_length, period_count, score_target, win_by_two, bonus_fouls, double_bonus_fouls,
+			foul_limit, timeouts_per, timeouts, short_timeouts, timeout_length, short_timeout_length,
+			ot_length, overtimes_before_tie, shot_clock_length, shot_clock_short, break_length,
+			halftime_length, ot_break_length, running_clock, stop_clock_under, stop_clock_margin)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+			$19, $20, $21, $22, $23, $24, $25, $26)
 		RETURNING id, created_at, version, status`
 
 	args := []any{
@@ -41,6 +45,25 @@ func (m *GameModel) Insert(game *Game) error {
 		game.PeriodLength,
 		game.PeriodCount,
 		game.ScoreTarget,
+		game.WinByTwo,
+		game.BonusFouls,
+		game.DoubleBonusFouls,
+		game.FoulLimit,
+		game.TimeoutsPer,
+		game.Timeouts,
+		game.ShortTimeouts,
+		game.TimeoutLength,
+		game.ShortTimeoutLength,
+		game.OtLength,
+		game.OvertimesBeforeTie,
+		game.ShotClockLength,
+		game.ShotClockShort,
+		game.BreakLength,
+		game.HalftimeLength,
+		game.OtBreakLength,
+		game.RunningClock,
+		game.StopClockUnder,
+		game.StopClockMargin,
 	}
 
 	err = tx.QueryRowContext(ctx, stmt, args...).Scan(
@@ -9,31 +9,80 @@ import (
 	"time"
 )
 
-// TODO implement allowedkeepers table & model
-
 type Game struct {
-	ID             int64         `json:"-"`
-	UserID         int64         `json:"-"`
-	PinID          pins.Pin      `json:"pin_id"`
-	CreatedAt      time.Time     `json:"-"`
-	Version        int64         `json:"-"`
-	Status         GameStatus    `json:"status"`
-	DateTime       time.Time     `json:"date_time"`
-	TeamSize       int64         `json:"team_size"`
-	Type           GameType      `json:"type"`
-	PeriodLength   *PeriodLength `json:"period_length,omitempty"`
-	PeriodCount    *int64        `json:"period_count,omitempty"`
-	ScoreTarget    *int64        `json:"score_target,omitempty"`
-	HomeTeamPin    *string       `json:"home_team_pin,omitempty"`
-	AwayTeamPin    *string       `json:"away_team_pin,omitempty"`
-	HomePlayerPins []string      `json:"-"`
-	AwayPlayerPins []string      `json:"-"`
-	Teams          struct {
+	ID                 int64         `json:"-"`
+	UserID             int64         `json:"-"`
+	PinID              pins.Pin      `json:"pin_id"`
+	CreatedAt          time.Time     `json:"-"`
+	Version            int64         `json:"-"`
+	Status             GameStatus    `json:"status"`
+	DateTime           time.Time     `json:"date_time"`
+	TeamSize           int64         `json:"team_size"`
+	Type               GameType      `json:"type"`
+	PeriodLength       *PeriodLength `json:"period_length,omitempty"`
+	PeriodCount        *int64        `json:"period_count,omitempty"`
+	ScoreTarget        *int64        `json:"score_target,omitempty"`
+	WinByTwo           bool          `json:"win_by_two,omitempty"`
+	BonusFouls         int64         `json:"bonus_fouls"`
+	DoubleBonusFouls   int64         `json:"double_bonus_fouls"`
+	FoulLimit          int64         `json:"foul_limit"`
+	TimeoutsPer        TimeoutsPer   `json:"timeouts_per"`
+	Timeouts           int64         `json:"timeouts"`
+	ShortTimeouts      int64         `json:"short_timeouts"`
+	TimeoutLength      *PeriodLength `json:"timeout_length"`
+	ShortTimeoutLength *PeriodLength `json:"short_timeout_length"`
+	OtLength           *PeriodLength `json:"ot_length,omitempty"`
+	OvertimesBeforeTie *int64        `json:"overtimes_before_tie,omitempty"`
+	ShotClockLength    *PeriodLength `json:"shot_clock_length,omitempty"`
+	ShotClockShort     *PeriodLength `json:"shot_clock_short,omitempty"`
+	BreakLength        *PeriodLength `json:"break_length,omitempty"`
+	HalftimeLength     *PeriodLength `json:"halftime_length,omitempty"`
+	OtBreakLength      *PeriodLength `json:"ot_break_length,omitempty"`
+	RunningClock       bool          `json:"running_clock,omitempty"`
+	StopClockUnder     *PeriodLength `json:"stop_clock_under,omitempty"`
+	StopClockMargin    *int64        `json:"stop_clock_margin,omitempty"`
+	HomeTeamPin        *string       `json:"home_team_pin,omitempty"`
+	AwayTeamPin        *string       `json:"away_team_pin,omitempty"`
+	HomePlayerPins     []string      `json:"-"`
+	AwayPlayerPins     []string      `json:"-"`
+	Teams              struct {
 		Home *Team `json:"home,omitempty"`
 		Away *Team `json:"away,omitempty"`
 	} `json:"teams,omitempty"`
 }
 
+// Default foul rules of a game created without them. A team's opponents are in the bonus once the
+// team has BonusFouls fouls in a period, and in the double bonus at DoubleBonusFouls. A player
+// fouls out at FoulLimit fouls. A limit of 0 is never reached.
+const (
+	DefaultBonusFouls       = 7
+	DefaultDoubleBonusFouls = 10
+	DefaultFoulLimit        = 5
+)
+
+// Default timeout rules of a game created without them. Each team gets Timeouts full and
+// ShortTimeouts short timeouts, either for the whole game or for each half. Overtime periods are
+// half as long as regular periods unless OtLength is set. A timed game may end tied unless
+// OvertimesBeforeTie is set, in which case that many overtimes must be played first. A timed game
+// has no break timers unless BreakLength, HalftimeLength or OtBreakLength are set. A timed game
+// with RunningClock set runs its clock through stoppages, except under StopClockUnder in the last
+// period and while the margin is under StopClockMargin points.
+const (
+	DefaultTimeoutsPer        = TimeoutsPerGame
+	DefaultTimeouts           = 4
+	DefaultShortTimeouts      = 0
+	DefaultTimeoutLength      = 60 * time.Second
+	DefaultShortTimeoutLength = 30 * time.Second
+)
+
+// TimeoutsPer is how often a team's timeouts are given back.
+type TimeoutsPer string
+
+const (
+	TimeoutsPerGame TimeoutsPer = "game"
+	TimeoutsPerHalf TimeoutsPer = "half"
+)
+
 func (g *Game) GetPlayerPins() (homeTeamPins, awayTeamPins []string) {
 	if g.Teams.Home == nil && g.Teams.Away == nil {
 		return nil, nil
@@ -53,14 +102,33 @@ func (g *Game) GetPlayerPins() (homeTeamPins, awayTeamPins []string) {
 }
 
 type GameDto struct {
-	DateTime     *time.Time    `json:"date_time"`
-	TeamSize     *int64        `json:"team_size"`
-	Type         *GameType     `json:"type"`
-	PeriodLength *PeriodLength `json:"period_length"`
-	PeriodCount  *int64        `json:"period_count"`
-	ScoreTarget  *int64        `json:"score_target"`
-	HomeTeamPin  *string       `json:"home_team_pin"`
-	AwayTeamPin  *string       `json:"away_team_pin"`
+	DateTime           *time.Time    `json:"date_time"`
+	TeamSize           *int64        `json:"team_size"`
+	Type               *GameType     `json:"type"`
+	PeriodLength       *PeriodLength `json:"period_length"`
+	PeriodCount        *int64        `json:"period_count"`
+	ScoreTarget        *int64        `json:"score_target"`
+	WinByTwo           *bool         `json:"win_by_two"`
+	BonusFouls         *int64        `json:"bonus_fouls"`
+	DoubleBonusFouls   *int64        `json:"double_bonus_fouls"`
+	FoulLimit          *int64        `json:"foul_limit"`
+	TimeoutsPer        *TimeoutsPer  `json:"timeouts_per"`
+	Timeouts           *int64        `json:"timeouts"`
+	ShortTimeouts      *int64        `json:"short_timeouts"`
+	TimeoutLength      *PeriodLength `json:"timeout_length"`
+	ShortTimeoutLength *PeriodLength `json:"short_timeout_length"`
+	OtLength           *PeriodLength `json:"ot_length"`
+	OvertimesBeforeTie *int64        `json:"overtimes_before_tie"`
+	ShotClockLength    *PeriodLength `json:"shot_clock_length"`
+	ShotClockShort     *PeriodLength `json:"shot_clock_short"`
+	BreakLength        *PeriodLength `json:"break_length"`
+	HalftimeLength     *PeriodLength `json:"halftime_length"`
+	OtBreakLength      *PeriodLength `json:"ot_break_length"`
+	RunningClock       *bool         `json:"running_clock"`
+	StopClockUnder     *PeriodLength `json:"stop_clock_under"`
+	StopClockMargin    *int64        `json:"stop_clock_margin"`
+	HomeTeamPin        *string       `json:"home_team_pin"`
+	AwayTeamPin        *string       `json:"away_team_pin"`
 }
 
 func (dto GameDto) validate(v *validator.Validator) {
@@ -78,6 +146,63 @@ func (dto GameDto) validate(v *validator.Validator) {
 		v.Check(dto.DateTime.After(time.Now()), "date_time", "must be in the future")
 	}
 
+	if dto.BonusFouls != nil {
+		v.Check(*dto.BonusFouls >= 0, "bonus_fouls", "must be 0 or greater")
+		v.Check(*dto.BonusFouls <= 20, "bonus_fouls", "must be 20 or less")
+	}
+	if dto.DoubleBonusFouls != nil {
+		v.Check(*dto.DoubleBonusFouls >= 0, "double_bonus_fouls", "must be 0 or greater")
+		v.Check(*dto.DoubleBonusFouls <= 20, "double_bonus_fouls", "must be 20 or less")
+		if dto.BonusFouls != nil && *dto.DoubleBonusFouls != 0 {
+			v.Check(*dto.DoubleBonusFouls > *dto.BonusFouls, "double_bonus_fouls",
+				"must be greater than bonus_fouls")
+		}
+	}
+	if dto.FoulLimit != nil {
+		v.Check(*dto.FoulLimit >= 0, "foul_limit", "must be 0 or greater")
+		v.Check(*dto.FoulLimit <= 10, "foul_limit", "must be 10 or less")
+	}
+
+	if dto.TimeoutsPer != nil {
+		v.Check(*dto.TimeoutsPer == TimeoutsPerGame || *dto.TimeoutsPer == TimeoutsPerHalf,
+			"timeouts_per", fmt.Sprintf(`must be one of the following: "%s", "%s"`,
+				TimeoutsPerGame, TimeoutsPerHalf))
+	}
+	if dto.Timeouts != nil {
+		v.Check(*dto.Timeouts >= 0, "timeouts", "must be 0 or greater")
+		v.Check(*dto.Timeouts <= 10, "timeouts", "must be 10 or less")
+	}
+	if dto.ShortTimeouts != nil {
+		v.Check(*dto.ShortTimeouts >= 0, "short_timeouts", "must be 0 or greater")
+		v.Check(*dto.ShortTimeouts <= 10, "short_timeouts", "must be 10 or less")
+	}
+	if dto.TimeoutLength != nil {
+		v.Check(dto.TimeoutLength.Duration() >= 10*time.Second, "timeout_length",
+			"must be 10 seconds or more")
+		v.Check(dto.TimeoutLength.Duration() <= 5*time.Minute, "timeout_length",
+			"must be 5 minutes or less")
+	}
+	if dto.ShortTimeoutLength != nil {
+		v.Check(dto.ShortTimeoutLength.Duration() >= 10*time.Second, "short_timeout_length",
+			"must be 10 seconds or more")
+		v.Check(dto.ShortTimeoutLength.Duration() <= 5*time.Minute, "short_timeout_length",
+			"must be 5 minutes or less")
+	}
+	if dto.ShotClockLength != nil {
+		v.Check(dto.ShotClockLength.Duration() >= 5*time.Second, "shot_clock_length",
+			"must be 5 seconds or more")
+		v.Check(dto.ShotClockLength.Duration() <= time.Minute, "shot_clock_length",
+			"must be 1 minute or less")
+	}
+	if dto.ShotClockShort != nil {
+		v.Check(dto.ShotClockShort.Duration() >= 5*time.Second, "shot_clock_short",
+			"must be 5 seconds or more")
+		if dto.ShotClockLength != nil {
+			v.Check(*dto.ShotClockShort < *dto.ShotClockLength, "shot_clock_short",
+				"must be less than shot_clock_length")
+		}
+	}
+
 	if dto.TeamSize != nil {
 		v.Check(*dto.TeamSize > 0, "team_size", "must be greater than 0")
 		v.Check(*dto.TeamSize <= 5, "team_size", "must be 5 or less")
@@ -92,6 +217,7 @@ func (dto GameDto) validate(v *validator.Validator) {
 			v.Check(dto.PeriodCount != nil, "period_count", "must be provided for timed game")
 			v.Check(dto.PeriodLength != nil, "period_length", "must be provided for timed game")
 			v.Check(dto.ScoreTarget == nil, "score_target", "cannot be provided for a timed game")
+			v.Check(dto.WinByTwo == nil, "win_by_two", "cannot be provided for a timed game")
 			if !v.Valid() {
 				return
 			}
@@ -101,6 +227,43 @@ func (dto GameDto) validate(v *validator.Validator) {
 
 			v.Check(*dto.PeriodCount > 0, "period_count", "must be greater than 0")
 			v.Check(*dto.PeriodCount <= 4, "period_count", "must be 4 or less")
+
+			if dto.OtLength != nil {
+				v.Check(dto.OtLength.Duration() > 0, "ot_length", "must be greater than 0")
+				v.Check(dto.OtLength.Duration() <= 30*time.Minute, "ot_length",
+					"must be 30 minutes or less")
+			}
+			if dto.OvertimesBeforeTie != nil {
+				v.Check(*dto.OvertimesBeforeTie >= 0, "overtimes_before_tie", "must be 0 or greater")
+				v.Check(*dto.OvertimesBeforeTie <= 10, "overtimes_before_tie", "must be 10 or less")
+			}
+			if dto.BreakLength != nil {
+				v.Check(dto.BreakLength.Duration() > 0, "break_length", "must be greater than 0")
+				v.Check(dto.BreakLength.Duration() <= 30*time.Minute, "break_length",
+					"must be 30 minutes or less")
+			}
+			if dto.HalftimeLength != nil {
+				v.Check(dto.HalftimeLength.Duration() > 0, "halftime_length",
+					"must be greater than 0")
+				v.Check(dto.HalftimeLength.Duration() <= 30*time.Minute, "halftime_length",
+					"must be 30 minutes or less")
+			}
+			if dto.OtBreakLength != nil {
+				v.Check(dto.OtBreakLength.Duration() > 0, "ot_break_length",
+					"must be greater than 0")
+				v.Check(dto.OtBreakLength.Duration() <= 30*time.Minute, "ot_break_length",
+					"must be 30 minutes or less")
+			}
+			if dto.StopClockUnder != nil {
+				v.Check(dto.StopClockUnder.Duration() > 0, "stop_clock_under",
+					"must be greater than 0")
+				v.Check(dto.StopClockUnder.Duration() <= dto.PeriodLength.Duration(),
+					"stop_clock_under", "must be period_length or less")
+			}
+			if dto.StopClockMargin != nil {
+				v.Check(*dto.StopClockMargin > 0, "stop_clock_margin", "must be greater than 0")
+				v.Check(*dto.StopClockMargin <= 50, "stop_clock_margin", "must be 50 or less")
+			}
 		}
 
 		if *dto.Type == GameTypeTarget {
@@ -108,6 +271,19 @@ func (dto GameDto) validate(v *validator.Validator) {
 			v.Check(dto.PeriodCount == nil, "period_count", "cannot be provided for a target game")
 			v.Check(dto.PeriodLength == nil, "period_length",
 				"cannot be provided for a target game")
+			v.Check(dto.OtLength == nil, "ot_length", "cannot be provided for a target game")
+			v.Check(dto.OvertimesBeforeTie == nil, "overtimes_before_tie",
+				"cannot be provided for a target game")
+			v.Check(dto.BreakLength == nil, "break_length", "cannot be provided for a target game")
+			v.Check(dto.HalftimeLength == nil, "halftime_length",
+				"cannot be provided for a target game")
+			v.Check(dto.OtBreakLength == nil, "ot_break_length",
+				"cannot be provided for a target game")
+			v.Check(dto.RunningClock == nil, "running_clock", "cannot be provided for a target game")
+			v.Check(dto.StopClockUnder == nil, "stop_clock_under",
+				"cannot be provided for a target game")
+			v.Check(dto.StopClockMargin == nil, "stop_clock_margin",
+				"cannot be provided for a target game")
 			if !v.Valid() {
 				return
 			}
@@ -117,8 +293,22 @@ func (dto GameDto) validate(v *validator.Validator) {
 		}
 	} else {
 		v.Check(dto.ScoreTarget == nil, "score_target", "cannot be provided without type field")
+		v.Check(dto.WinByTwo == nil, "win_by_two", "cannot be provided without type field")
 		v.Check(dto.PeriodCount == nil, "period_count", "cannot be provided without type field")
 		v.Check(dto.PeriodLength == nil, "period_length", "cannot be provided without type field")
+		v.Check(dto.OtLength == nil, "ot_length", "cannot be provided without type field")
+		v.Check(dto.OvertimesBeforeTie == nil, "overtimes_before_tie",
+			"cannot be provided without type field")
+		v.Check(dto.BreakLength == nil, "break_length", "cannot be provided without type field")
+		v.Check(dto.HalftimeLength == nil, "halftime_length",
+			"cannot be provided without type field")
+		v.Check(dto.OtBreakLength == nil, "ot_break_length",
+			"cannot be provided without type field")
+		v.Check(dto.RunningClock == nil, "running_clock", "cannot be provided without type field")
+		v.Check(dto.StopClockUnder == nil, "stop_clock_under",
+			"cannot be provided without type field")
+		v.Check(dto.StopClockMargin == nil, "stop_clock_margin",
+			"cannot be provided without type field")
 	}
 }
 
@@ -163,6 +353,146 @@ func (dto GameDto) Merge(v *validator.Validator, g *Game) {
 			g.ScoreTarget = dto.ScoreTarget
 		}
 	}
+	if dto.WinByTwo != nil {
+		if *dto.WinByTwo == g.WinByTwo {
+			v.AddError("win_by_two", "cannot be old value")
+		} else {
+			g.WinByTwo = *dto.WinByTwo
+		}
+	}
+	if dto.BonusFouls != nil {
+		if *dto.BonusFouls == g.BonusFouls {
+			v.AddError("bonus_fouls", "cannot be old value")
+		} else {
+			g.BonusFouls = *dto.BonusFouls
+		}
+	}
+	if dto.DoubleBonusFouls != nil {
+		if *dto.DoubleBonusFouls == g.DoubleBonusFouls {
+			v.AddError("double_bonus_fouls", "cannot be old value")
+		} else {
+			g.DoubleBonusFouls = *dto.DoubleBonusFouls
+		}
+	}
+	if dto.FoulLimit != nil {
+		if *dto.FoulLimit == g.FoulLimit {
+			v.AddError("foul_limit", "cannot be old value")
+		} else {
+			g.FoulLimit = *dto.FoulLimit
+		}
+	}
+	if g.DoubleBonusFouls != 0 && g.DoubleBonusFouls <= g.BonusFouls {
+		v.AddError("double_bonus_fouls", "must be greater than bonus_fouls")
+	}
+	if dto.TimeoutsPer != nil {
+		if *dto.TimeoutsPer == g.TimeoutsPer {
+			v.AddError("timeouts_per", "cannot be old value")
+		} else {
+			g.TimeoutsPer = *dto.TimeoutsPer
+		}
+	}
+	if dto.Timeouts != nil {
+		if *dto.Timeouts == g.Timeouts {
+			v.AddError("timeouts", "cannot be old value")
+		} else {
+			g.Timeouts = *dto.Timeouts
+		}
+	}
+	if dto.ShortTimeouts != nil {
+		if *dto.ShortTimeouts == g.ShortTimeouts {
+			v.AddError("short_timeouts", "cannot be old value")
+		} else {
+			g.ShortTimeouts = *dto.ShortTimeouts
+		}
+	}
+	if dto.TimeoutLength != nil {
+		if g.TimeoutLength != nil && *dto.TimeoutLength == *g.TimeoutLength {
+			v.AddError("timeout_length", "cannot be old value")
+		} else {
+			g.TimeoutLength = dto.TimeoutLength
+		}
+	}
+	if dto.ShortTimeoutLength != nil {
+		if g.ShortTimeoutLength != nil && *dto.ShortTimeoutLength == *g.ShortTimeoutLength {
+			v.AddError("short_timeout_length", "cannot be old value")
+		} else {
+			g.ShortTimeoutLength = dto.ShortTimeoutLength
+		}
+	}
+	if dto.OtLength != nil {
+		if g.OtLength != nil && *dto.OtLength == *g.OtLength {
+			v.AddError("ot_length", "cannot be old value")
+		} else {
+			g.OtLength = dto.OtLength
+		}
+	}
+	if dto.OvertimesBeforeTie != nil {
+		if g.OvertimesBeforeTie != nil && *dto.OvertimesBeforeTie == *g.OvertimesBeforeTie {
+			v.AddError("overtimes_before_tie", "cannot be old value")
+		} else {
+			g.OvertimesBeforeTie = dto.OvertimesBeforeTie
+		}
+	}
+	if dto.ShotClockLength != nil {
+		if g.ShotClockLength != nil && *dto.ShotClockLength == *g.ShotClockLength {
+			v.AddError("shot_clock_length", "cannot be old value")
+		} else {
+			g.ShotClockLength = dto.ShotClockLength
+		}
+	}
+	if dto.ShotClockShort != nil {
+		if g.ShotClockShort != nil && *dto.ShotClockShort == *g.ShotClockShort {
+			v.AddError("shot_clock_short", "cannot be old value")
+		} else {
+			g.ShotClockShort = dto.ShotClockShort
+		}
+	}
+	if g.ShotClockShort != nil &&
+		(g.ShotClockLength == nil || *g.ShotClockShort >= *g.ShotClockLength) {
+		v.AddError("shot_clock_short", "must be less than shot_clock_length")
+	}
+	if dto.BreakLength != nil {
+		if g.BreakLength != nil && *dto.BreakLength == *g.BreakLength {
+			v.AddError("break_length", "cannot be old value")
+		} else {
+			g.BreakLength = dto.BreakLength
+		}
+	}
+	if dto.HalftimeLength != nil {
+		if g.HalftimeLength != nil && *dto.HalftimeLength == *g.HalftimeLength {
+			v.AddError("halftime_length", "cannot be old value")
+		} else {
+			g.HalftimeLength = dto.HalftimeLength
+		}
+	}
+	if dto.OtBreakLength != nil {
+		if g.OtBreakLength != nil && *dto.OtBreakLength == *g.OtBreakLength {
+			v.AddError("ot_break_length", "cannot be old value")
+		} else {
+			g.OtBreakLength = dto.OtBreakLength
+		}
+	}
+	if dto.RunningClock != nil {
+		if *dto.RunningClock == g.RunningClock {
+			v.AddError("running_clock", "cannot be old value")
+		} else {
+			g.RunningClock = *dto.RunningClock
+		}
+	}
+	if dto.StopClockUnder != nil {
+		if g.StopClockUnder != nil && *dto.StopClockUnder == *g.StopClockUnder {
+			v.AddError("stop_clock_under", "cannot be old value")
+		} else {
+			g.StopClockUnder = dto.StopClockUnder
+		}
+	}
+	if dto.StopClockMargin != nil {
+		if g.StopClockMargin != nil && *dto.StopClockMargin == *g.StopClockMargin {
+			v.AddError("stop_clock_margin", "cannot be old value")
+		} else {
+			g.StopClockMargin = dto.StopClockMargin
+		}
+	}
 	if dto.HomeTeamPin != nil {
 		g.HomeTeamPin = dto.HomeTeamPin
 	}
@@ -192,7 +522,7 @@ func (dto GameDto) Convert(v *validator.Validator) *Game {
 		return nil
 	}
 
-	var game *Game
+	game := &Game{}
 	game.DateTime = *dto.DateTime
 	game.TeamSize = *dto.TeamSize
 	game.Type = *dto.Type
@@ -205,6 +535,86 @@ func (dto GameDto) Convert(v *validator.Validator) *Game {
 	if dto.ScoreTarget != nil {
 		game.ScoreTarget = dto.ScoreTarget
 	}
+	if dto.WinByTwo != nil {
+		game.WinByTwo = *dto.WinByTwo
+	}
+	game.BonusFouls = DefaultBonusFouls
+	game.DoubleBonusFouls = DefaultDoubleBonusFouls
+	game.FoulLimit = DefaultFoulLimit
+	if dto.BonusFouls != nil {
+		game.BonusFouls = *dto.BonusFouls
+	}
+	if dto.DoubleBonusFouls != nil {
+		game.DoubleBonusFouls = *dto.DoubleBonusFouls
+	}
+	if dto.FoulLimit != nil {
+		game.FoulLimit = *dto.FoulLimit
+	}
+	if game.DoubleBonusFouls != 0 && game.DoubleBonusFouls <= game.BonusFouls {
+		v.AddError("double_bonus_fouls", "must be greater than bonus_fouls")
+		return nil
+	}
+	timeoutLength := PeriodLength(DefaultTimeoutLength)
+	shortTimeoutLength := PeriodLength(DefaultShortTimeoutLength)
+	game.TimeoutsPer = DefaultTimeoutsPer
+	game.Timeouts = DefaultTimeouts
+	game.ShortTimeouts = DefaultShortTimeouts
+	game.TimeoutLength = &timeoutLength
+	game.ShortTimeoutLength = &shortTimeoutLength
+	if dto.TimeoutsPer != nil {
+		game.TimeoutsPer = *dto.TimeoutsPer
+	}
+	if dto.Timeouts != nil {
+		game.Timeouts = *dto.Timeouts
+	}
+	if dto.ShortTimeouts != nil {
+		game.ShortTimeouts = *dto.ShortTimeouts
+	}
+	if dto.TimeoutLength != nil {
+		game.TimeoutLength = dto.TimeoutLength
+	}
+	if dto.ShortTimeoutLength != nil {
+		game.ShortTimeoutLength = dto.ShortTimeoutLength
+	}
+	if dto.OtLength != nil {
+		game.OtLength = dto.OtLength
+	}
+	if dto.OvertimesBeforeTie != nil {
+		game.OvertimesBeforeTie = dto.OvertimesBeforeTie
+	}
+	if dto.ShotClockShort != nil && dto.ShotClockLength == nil {
+		v.AddError("shot_clock_short", "cannot be provided without shot_clock_length")
+		return nil
+	}
+	if dto.ShotClockLength != nil {
+		game.ShotClockLength = dto.ShotClockLength
+	}
+	if dto.ShotClockShort != nil {
+		game.ShotClockShort = dto.ShotClockShort
+	}
+	if dto.BreakLength != nil {
+		game.BreakLength = dto.BreakLength
+	}
+	if dto.HalftimeLength != nil {
+		game.HalftimeLength = dto.HalftimeLength
+	}
+	if dto.OtBreakLength != nil {
+		game.OtBreakLength = dto.OtBreakLength
+	}
+	if (dto.StopClockUnder != nil || dto.StopClockMargin != nil) &&
+		(dto.RunningClock == nil || !*dto.RunningClock) {
+		v.AddError("running_clock", "must be true to provide stop_clock_under or stop_clock_margin")
+		return nil
+	}
+	if dto.RunningClock != nil {
+		game.RunningClock = *dto.RunningClock
+	}
+	if dto.StopClockUnder != nil {
+		game.StopClockUnder = dto.StopClockUnder
+	}
+	if dto.StopClockMargin != nil {
+		game.StopClockMargin = dto.StopClockMargin
+	}
 	if dto.HomeTeamPin != nil {
 		game.HomeTeamPin = dto.HomeTeamPin
 	}
@@ -269,3 +679,26 @@ func (s GameTeamSide) String() string {
 		return ""
 	}
 }
+
+// MarshalText encodes a GameTeamSide as "home" or "away", both as a JSON value and as a JSON
+// object key.
+func (s GameTeamSide) MarshalText() ([]byte, error) {
+	if s != TeamHome && s != TeamAway {
+		return nil, errors.New("invalid game team side")
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalJSON decodes a GameTeamSide from "home" or "away", or from 0 or 1 as it was encoded
+// before it had a text form.
+func (s *GameTeamSide) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"home"`, "0":
+		*s = TeamHome
+	case `"away"`, "1":
+		*s = TeamAway
+	default:
+		return errors.New("invalid game team side")
+	}
+	return nil
+}
@@ -11,14 +11,23 @@ func (m *GameModel) Update(game *Game) error {
 	stmt := `
 		UPDATE games
 			SET date_time = $1, team_size = $2, period_length = $3, period_count = $4,
-				score_target = $5
-			WHERE user_id = $6
-			  	AND id = $7
-				AND version = $8
+				score_target = $5, win_by_two = $6, bonus_fouls = $7, double_bonus_fouls = $8,
+				foul_limit = $9, timeouts_per = $10, timeouts = $11, short_timeouts = $12,
+				timeout_length = $13, short_timeout_length = $14, ot_length = $15,
+				overtimes_before_tie = $16, shot_clock_length = $17, shot_clock_short = $18,
+				break_length = $19, halftime_length = $20, ot_break_length = $21,
+				running_clock = $22, stop_clock_under = $23, stop_clock_margin = $24
+			WHERE user_id = $25
+			  	AND id = $26
+				AND version = $27
 			RETURNING version`
 
 	args := []any{game.DateTime, game.TeamSize, game.PeriodLength, game.PeriodCount, game.ScoreTarget,
-		game.UserID, game.ID, game.Version}
+		game.WinByTwo, game.BonusFouls, game.DoubleBonusFouls, game.FoulLimit, game.TimeoutsPer,
+		game.Timeouts, game.ShortTimeouts, game.TimeoutLength, game.ShortTimeoutLength, game.OtLength,
+		game.OvertimesBeforeTie, game.ShotClockLength, game.ShotClockShort, game.BreakLength,
+		game.HalftimeLength, game.OtBreakLength, game.RunningClock, game.StopClockUnder,
+		game.StopClockMargin, game.UserID, game.ID, game.Version}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -143,3 +152,32 @@ func (m *GameModel) StartGameInDB(g *Game) error {
 
 	return nil
 }
+
+// FinishGameInDB marks an in-progress game as FINISHED and saves its final score and box score.
+func (m *GameModel) FinishGameInDB(g *Game, homeScore, awayScore int, boxScore []byte) error {
+	stmt := `
+		UPDATE games
+		SET status = $1, home_score = $2, away_score = $3, box_score = $4, version = version + 1
+		WHERE id = $5 AND status = $6`
+
+	args := []any{FINISHED, homeScore, awayScore, boxScore, g.ID, INPROGRESS}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrEditConflict
+	}
+
+	g.Status = FINISHED
+	return nil
+}
@@ -14,6 +14,8 @@ type Models struct {
 	Players     PlayerModel
 	Teams       TeamModel
 	Games       GameModel
+	GameEvents  GameEventModel
+	GameKeepers GameKeeperModel
 	Tokens      TokenModel
 	Pins        PinModel
 	Permissions PermissionModel
@@ -32,6 +34,8 @@ func NewModels(initDb *sql.DB) Models {
 		Players:     PlayerModel{db: initDb},
 		Teams:       TeamModel{db: initDb},
 		Games:       GameModel{db: initDb},
+		GameEvents:  GameEventModel{db: initDb},
+		GameKeepers: GameKeeperModel{db: initDb},
 		Tokens:      TokenModel{db: initDb},
 		Pins:        PinModel{db: initDb},
 		Permissions: PermissionModel{db: initDb},
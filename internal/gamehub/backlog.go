@@ -0,0 +1,42 @@
+package gamehub
+
+// frame is a message broadcast to a Hub's watchers, numbered so a watcher that reconnects can
+// resume from the last frame it received.
+type frame struct {
+	seq int64
+	msg []byte
+}
+
+// backlog numbers the frames broadcast to a Hub's watchers and keeps the most recent of them. It
+// is only used from the hub's Run loop. Frame numbers restart when a Hub is rebuilt, so epoch
+// tells frames of different Hubs of the same game apart.
+type backlog struct {
+	epoch  int64
+	seq    int64
+	frames []frame
+}
+
+// append numbers msg as the next frame and keeps it.
+func (b *backlog) append(msg []byte) frame {
+	b.seq++
+	f := frame{seq: b.seq, msg: msg}
+	b.frames = append(b.frames, f)
+	if len(b.frames) > backlogSize {
+		b.frames = b.frames[len(b.frames)-backlogSize:]
+	}
+	return f
+}
+
+// since returns the frames broadcast after seq, or false if some of them are no longer kept.
+func (b *backlog) since(seq int64) ([]frame, bool) {
+	if seq < 0 || seq > b.seq {
+		return nil, false
+	}
+	if seq == b.seq {
+		return nil, true
+	}
+	if len(b.frames) == 0 || b.frames[0].seq > seq+1 {
+		return nil, false
+	}
+	return b.frames[seq+1-b.frames[0].seq:], true
+}
@@ -0,0 +1,47 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"testing"
+)
+
+func TestBacklogSince(t *testing.T) {
+	var b backlog
+	for i := 0; i < backlogSize+10; i++ {
+		b.append([]byte("msg"))
+	}
+	first := int64(11)
+
+	tests := []struct {
+		name  string
+		seq   int64
+		count int
+		ok    bool
+	}{
+		{name: "Up To Date", seq: backlogSize + 10, count: 0, ok: true},
+		{name: "Behind", seq: backlogSize + 7, count: 3, ok: true},
+		{name: "Oldest Kept", seq: first - 1, count: backlogSize, ok: true},
+		{name: "No Longer Kept", seq: first - 2, ok: false},
+		{name: "Ahead", seq: backlogSize + 11, ok: false},
+		{name: "Negative", seq: -1, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames, ok := b.since(tt.seq)
+			assert.Equal(t, ok, tt.ok)
+			assert.Equal(t, len(frames), tt.count)
+			if len(frames) > 0 {
+				assert.Equal(t, frames[0].seq, tt.seq+1)
+				assert.Equal(t, frames[len(frames)-1].seq, b.seq)
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		var b backlog
+		frames, ok := b.since(0)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, len(frames), 0)
+	})
+}
@@ -0,0 +1,89 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/stats"
+	"strings"
+)
+
+// GameCompoundEvent applies several stat changes, to one or more players, as a single play, such
+// as a made shot and its assist, or a steal and the opposing turnover. Either every change is
+// applied or none are, watchers are sent one update for the whole play, and it is undone as a
+// unit.
+type GameCompoundEvent struct {
+	Stats []GameStatEvent `json:"stats"`
+}
+
+func (e GameCompoundEvent) validate() error {
+	if len(e.Stats) < 2 || len(e.Stats) > maxCompoundStats {
+		return ErrEventValidationFailed
+	}
+	for _, s := range e.Stats {
+		err := s.validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e GameCompoundEvent) eventType() GameEventType {
+	return compound
+}
+
+func (e GameCompoundEvent) reverse() (GameEvent, error) {
+	reversed := GameCompoundEvent{Stats: make([]GameStatEvent, 0, len(e.Stats))}
+	for i := len(e.Stats) - 1; i >= 0; i-- {
+		r, err := e.Stats[i].reverse()
+		if err != nil {
+			return nil, err
+		}
+		reversed.Stats = append(reversed.Stats, r.(GameStatEvent))
+	}
+	return reversed, nil
+}
+
+func (e GameCompoundEvent) execute(h *Hub) error {
+	pending := make(map[statChange]int)
+	for _, s := range e.Stats {
+		c := statChange{s.PlayerPin, s.Stat}
+		err := s.check(h, pending[c])
+		if err != nil {
+			return err
+		}
+		switch s.Action {
+		case add:
+			pending[c]++
+		case subtract:
+			pending[c]--
+		}
+	}
+
+	homeBefore, awayBefore := h.Stats.GetScore()
+	changes := make([]statChange, 0, len(e.Stats))
+	fouled := false
+	for _, s := range e.Stats {
+		s.apply(h)
+		changes = append(changes, statChange{s.PlayerPin, s.Stat})
+		if s.Stat == stats.Foul {
+			fouled = true
+		}
+	}
+
+	h.statsDelta(changes...)
+	h.scored(homeBefore, awayBefore)
+	if fouled {
+		h.sendFouls()
+	}
+	return nil
+}
+
+// describe joins the descriptions of each stat in the play, e.g. "J. Smith made 3PT (12 PTS),
+// K. Jones assist". The play belongs to the player of the first stat.
+func (e GameCompoundEvent) describe(h *Hub) (string, string) {
+	descriptions := make([]string, 0, len(e.Stats))
+	for _, s := range e.Stats {
+		_, description := s.describe(h)
+		descriptions = append(descriptions, description)
+	}
+	return e.Stats[0].PlayerPin, strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,254 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/data"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrEventNotFound      = errors.New("event not found")
+	ErrEventIrreversible  = errors.New("event cannot be reversed")
+	ErrEventAlreadyVoided = errors.New("event has already been voided")
+	ErrEventNotVoided     = errors.New("event has not been voided")
+	ErrNothingToUndo      = errors.New("no event to undo")
+	ErrNothingToRedo      = errors.New("no event to redo")
+	ErrEventIsCorrection  = errors.New("undo, redo and void events cannot be voided")
+)
+
+// historyEntry is an executed GameEvent, the period it executed in and the players who were on the
+// floor, and whether its effect has since been reversed.
+type historyEntry struct {
+	event  GameEvent
+	period int64
+	active map[data.GameTeamSide]lineup
+	voided bool
+}
+
+// eventHistory holds every executed GameEvent of a Hub by its server-assigned ID, along with the
+// IDs of undone events that can be redone.
+type eventHistory struct {
+	entries map[int64]*historyEntry
+	order   []int64
+	redo    []int64
+}
+
+func newEventHistory() *eventHistory {
+	return &eventHistory{
+		entries: make(map[int64]*historyEntry),
+		order:   make([]int64, 0),
+		redo:    make([]int64, 0),
+	}
+}
+
+// add stores an executed event under id, with the period it executed in and the players on the
+// floor. Any new event other than a correction clears the redo stack.
+func (eh *eventHistory) add(id int64, e GameEvent, period int64,
+	active map[data.GameTeamSide]lineup) {
+	eh.entries[id] = &historyEntry{event: e, period: period, active: active}
+	eh.order = append(eh.order, id)
+	if !isCorrection(e) {
+		eh.redo = eh.redo[:0]
+	}
+}
+
+// last returns the ID of the most recent event accepted by filter that has not been voided and is
+// not itself a correction. If that event cannot be reversed, last returns ErrEventIrreversible
+// rather than reaching past it to an older event the keeper did not mean to undo.
+func (eh *eventHistory) last(filter func(GameEvent) bool) (int64, error) {
+	for i := len(eh.order) - 1; i >= 0; i-- {
+		entry := eh.entries[eh.order[i]]
+		if entry.voided || isCorrection(entry.event) || !filter(entry.event) {
+			continue
+		}
+		if _, err := entry.event.reverse(); err != nil {
+			return 0, ErrEventIrreversible
+		}
+		return eh.order[i], nil
+	}
+	return 0, ErrNothingToUndo
+}
+
+func isCorrection(e GameEvent) bool {
+	switch e.(type) {
+	case *GameUndoEvent, *GameRedoEvent, *GameVoidEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// void reverses the effect of the event with id and marks it voided.
+func (h *Hub) void(id int64) error {
+	entry, ok := h.history.entries[id]
+	if !ok {
+		return ErrEventNotFound
+	}
+	if isCorrection(entry.event) {
+		return ErrEventIsCorrection
+	}
+	if entry.voided {
+		return ErrEventAlreadyVoided
+	}
+
+	reversed, err := entry.event.reverse()
+	if err != nil {
+		return err
+	}
+	err = h.correct(reversed, entry)
+	if err != nil {
+		return err
+	}
+	entry.voided = true
+	h.Plays.setVoided(id, true)
+	h.Shots.setVoided(id, true)
+
+	msg := h.message(msgVoided, envelope{"event_id": id})
+	h.ToAllKeepers(msg)
+	h.ToAllWatchers(msg)
+	return nil
+}
+
+// restore executes a voided event with id again and clears its voided mark.
+func (h *Hub) restore(id int64) error {
+	entry, ok := h.history.entries[id]
+	if !ok {
+		return ErrEventNotFound
+	}
+	if !entry.voided {
+		return ErrEventNotVoided
+	}
+
+	err := h.correct(entry.event, entry)
+	if err != nil {
+		return err
+	}
+	entry.voided = false
+	h.Shots.setVoided(id, false)
+
+	msg := h.message(msgRestored, envelope{"event_id": id})
+	h.ToAllKeepers(msg)
+	h.ToAllWatchers(msg)
+	for _, play := range h.Plays.setVoided(id, false) {
+		msg := h.message(msgPlay, play)
+		h.ToAllKeepers(msg)
+		h.ToAllWatchers(msg)
+	}
+	return nil
+}
+
+// correct executes e, which reverses or restores the event of entry, as of when that event first
+// executed: score changes are counted against the players then on the floor, and fouls in the
+// period it executed in.
+func (h *Hub) correct(e GameEvent, entry *historyEntry) error {
+	h.correcting = entry
+	defer func() {
+		h.correcting = nil
+	}()
+	return e.execute(h)
+}
+
+// GameVoidEvent reverses the effect of a specific earlier event.
+type GameVoidEvent struct {
+	EventID int64 `json:"event_id"`
+}
+
+func (e *GameVoidEvent) validate() error {
+	if e.EventID <= 0 {
+		return ErrEventValidationFailed
+	}
+	return nil
+}
+
+func (e *GameVoidEvent) eventType() GameEventType {
+	return void
+}
+
+func (e *GameVoidEvent) reverse() (GameEvent, error) {
+	return nil, ErrEventIrreversible
+}
+
+func (e *GameVoidEvent) execute(h *Hub) error {
+	return h.void(e.EventID)
+}
+
+// GameUndoEvent voids the most recent event that has not been voided, and allows it to be redone.
+// It fails with ErrEventIrreversible if that event cannot be reversed, such as Play or SetClock.
+// EventID is assigned when the undo is authorized, so replaying it from the event log always undoes
+// the same event. Keepers cannot send an EventID.
+type GameUndoEvent struct {
+	EventID int64 `json:"event_id,omitempty"`
+}
+
+func (e *GameUndoEvent) validate() error {
+	if e.EventID < 0 {
+		return ErrEventValidationFailed
+	}
+	return nil
+}
+
+func (e *GameUndoEvent) eventType() GameEventType {
+	return undo
+}
+
+func (e *GameUndoEvent) reverse() (GameEvent, error) {
+	return nil, ErrEventIrreversible
+}
+
+func (e *GameUndoEvent) execute(h *Hub) error {
+	if e.EventID == 0 {
+		id, err := h.history.last(func(GameEvent) bool { return true })
+		if err != nil {
+			return err
+		}
+		e.EventID = id
+	}
+
+	err := h.void(e.EventID)
+	if err != nil {
+		return err
+	}
+	h.history.redo = append(h.history.redo, e.EventID)
+	return nil
+}
+
+// GameRedoEvent executes the most recently undone event again. EventID is assigned when the redo
+// is authorized, so replaying it from the event log always redoes the same event. Keepers cannot
+// send an EventID.
+type GameRedoEvent struct {
+	EventID int64 `json:"event_id,omitempty"`
+}
+
+func (e *GameRedoEvent) validate() error {
+	if e.EventID < 0 {
+		return ErrEventValidationFailed
+	}
+	return nil
+}
+
+func (e *GameRedoEvent) eventType() GameEventType {
+	return redo
+}
+
+func (e *GameRedoEvent) reverse() (GameEvent, error) {
+	return nil, ErrEventIrreversible
+}
+
+func (e *GameRedoEvent) execute(h *Hub) error {
+	stack := h.history.redo
+	if len(stack) == 0 {
+		return ErrNothingToRedo
+	}
+	if e.EventID == 0 {
+		e.EventID = stack[len(stack)-1]
+	}
+
+	err := h.restore(e.EventID)
+	if err != nil {
+		return err
+	}
+	h.history.redo = slices.DeleteFunc(stack, func(id int64) bool {
+		return id == e.EventID
+	})
+	return nil
+}
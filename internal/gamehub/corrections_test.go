@@ -0,0 +1,115 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/clock"
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"testing"
+)
+
+func TestCorrections(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    []GameEvent
+		err       error
+		points    int
+		plusMinus int
+	}{
+		{
+			name:   "Void",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade), &GameVoidEvent{EventID: 1}},
+		},
+		{
+			name: "Void Twice",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade), &GameVoidEvent{EventID: 1},
+				&GameVoidEvent{EventID: 1}},
+			err: ErrEventAlreadyVoided,
+		},
+		{
+			name:   "Void Unknown",
+			events: []GameEvent{&GameVoidEvent{EventID: 3}},
+			err:    ErrEventNotFound,
+		},
+		{
+			name: "Void Correction",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade), &GameUndoEvent{},
+				&GameVoidEvent{EventID: 2}},
+			err: ErrEventIsCorrection,
+		},
+		{
+			name: "Void Subbed Out Player",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade),
+				&GameSubstitutionEvent{Side: data.TeamHome, In: "h3", Out: "h1"},
+				&GameVoidEvent{EventID: 1}},
+		},
+		{
+			name: "Undo",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade),
+				statEvent("h1", stats.ThreePointMade), &GameUndoEvent{}},
+			points:    2,
+			plusMinus: 2,
+		},
+		{
+			name: "Undo Irreversible",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade),
+				&GameClockEvent{Action: clock.Reset}, &GameUndoEvent{}},
+			err:       ErrEventIrreversible,
+			points:    2,
+			plusMinus: 2,
+		},
+		{
+			name: "Undo Clock Adjustment",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade),
+				&GameClockEvent{Action: clock.AddMin}, &GameUndoEvent{}},
+			points:    2,
+			plusMinus: 2,
+		},
+		{
+			name:   "Nothing To Undo",
+			events: []GameEvent{&GameUndoEvent{}},
+			err:    ErrNothingToUndo,
+		},
+		{
+			name: "Redo",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade), &GameUndoEvent{},
+				&GameRedoEvent{}},
+			points:    2,
+			plusMinus: 2,
+		},
+		{
+			name: "Redo Twice",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade), &GameUndoEvent{},
+				&GameRedoEvent{}, &GameRedoEvent{}},
+			err:       ErrNothingToRedo,
+			points:    2,
+			plusMinus: 2,
+		},
+		{
+			name: "New Event Clears Redo",
+			events: []GameEvent{statEvent("h1", stats.TwoPointMade), &GameUndoEvent{},
+				statEvent("h1", stats.Point), &GameRedoEvent{}},
+			err:       ErrNothingToRedo,
+			points:    1,
+			plusMinus: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHub(t)
+
+			var err error
+			for i, e := range tt.events {
+				_, err = execute(h, e)
+				if i < len(tt.events)-1 {
+					assert.NilError(t, err)
+				}
+			}
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, h.Stats.GetPlayerStat("h1", "Pts"), any(tt.points))
+			assert.Equal(t, h.LineupStats.report().PlusMinus["h1"], tt.plusMinus)
+			assert.Equal(t, h.LineupStats.report().PlusMinus["h3"], 0)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/clock"
+	"ScoreTableApi/internal/data"
+	json2 "encoding/json"
+	"errors"
+)
+
+var ErrGameTied = errors.New("game cannot end tied before its overtimes are played")
+
+// GameEndEvent finishes the game. Once it is executed the hub saves the final score and box score,
+// sends a final message to every connection and closes them, and stops.
+type GameEndEvent struct{}
+
+func (e *GameEndEvent) validate() error {
+	return nil
+}
+
+func (e *GameEndEvent) eventType() GameEventType {
+	return end
+}
+
+func (e *GameEndEvent) reverse() (GameEvent, error) {
+	return nil, ErrEventIrreversible
+}
+
+func (e *GameEndEvent) execute(h *Hub) error {
+	if h.Clock.GetState() == clock.StatePlaying {
+		return ErrClockRunning
+	}
+	if h.Game.Type == data.GameTypeTimed && h.Game.OvertimesBeforeTie != nil {
+		home, away := h.Stats.GetScore()
+		if home == away && h.Clock.GetPeriod() < *h.Game.PeriodCount+*h.Game.OvertimesBeforeTie {
+			return ErrGameTied
+		}
+	}
+	h.ended = true
+	return nil
+}
+
+// finish closes the hub after a GameEndEvent. The game is marked FINISHED with its final score and
+// box score, keepers and watchers are sent a final message and disconnected, and the hub's
+// goroutines are stopped.
+func (h *Hub) finish() {
+	home, away := h.Stats.GetScore()
+	dto := h.Stats.GetDto()
+	boxScore, err := json2.Marshal(dto)
+	if err == nil {
+		err = h.models.Games.FinishGameInDB(h.Game, home, away, boxScore)
+	}
+	if err != nil {
+		h.logger.PrintError(err, map[string]string{"game": h.Game.PinID.Pin})
+	}
+
+	msg := h.message(msgFinal, envelope{
+		"score": envelope{"home": home, "away": away},
+		"stats": dto,
+	})
+	h.ToAllKeepers(msg)
+	h.ToAllWatchers(msg)
+
+	h.stop()
+}
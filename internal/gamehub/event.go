@@ -4,12 +4,25 @@ import (
 	"ScoreTableApi/internal/clock"
 	"ScoreTableApi/internal/data"
 	"ScoreTableApi/internal/stats"
-	json2 "encoding/json"
-	"fmt"
+	"errors"
+	"strconv"
 )
 
 type GameEvent interface {
-	execute(hub *Hub)
+	validate() error
+	execute(hub *Hub) error
+	eventType() GameEventType
+	// reverse returns a GameEvent that undoes the effect of this one, or ErrEventIrreversible.
+	reverse() (GameEvent, error)
+}
+
+// keeperEvent is a GameEvent received from the Keeper that sent it, along with the client's
+// correlation ID for the message. If the message could not be parsed, err is set instead.
+type keeperEvent struct {
+	GameEvent
+	keeper *Keeper
+	id     string
+	err    error
 }
 
 type GameEventType int
@@ -18,103 +31,24 @@ const (
 	stat GameEventType = iota
 	gameClock
 	substitution
+	undo
+	redo
+	void
+	end
+	compound
 )
 
-type GenericEvent map[string]any
-
-func (e GenericEvent) parseEvent() (GameEvent, error) {
-	eventType, err := checkAndAssertIntFromMap(e, "type")
-	if err != nil {
-		fmt.Printf("%s", err)
-		return GameStatEvent{}, err
-	}
-	fmt.Printf("event type: %d", eventType)
-
-	switch GameEventType(eventType) {
-	case stat:
-		event := &GameStatEvent{}
-		pin, err := checkAndAssertStringFromMap(e, "player_pin")
-		if err != nil {
-			return GameStatEvent{}, ErrEventParseFailed
-		}
-		event.PlayerPin = pin
-
-		action, err := checkAndAssertIntFromMap(e, "action")
-		if err != nil {
-			return GameStatEvent{}, ErrEventParseFailed
-
-		}
-		event.Action = GameStatAction(action)
-		selectedStat, err := checkAndAssertStringFromMap(e, "stat")
-		if err != nil {
-			return GameStatEvent{}, ErrEventParseFailed
-		}
-		event.Stat = stats.PrimitiveStat(selectedStat)
-
-		err = event.validate()
-		if err != nil {
-			return GameStatEvent{}, ErrEventParseFailed
-		}
-		return event, nil
-	case gameClock:
-		event := &GameClockEvent{}
-
-		action, err := checkAndAssertIntFromMap(e, "action")
-		if err != nil {
-			return GameClockEvent{}, ErrEventParseFailed
-		}
-		event.Action = clock.Control(action)
-
-		value, _ := checkAndAssertStringFromMap(e, "value")
-		if value == "" {
-			event.Value = nil
-		} else {
-			event.Value = &value
-		}
-
-		err = event.validate()
-		if err != nil {
-			return GameClockEvent{}, ErrEventParseFailed
-		}
-		return event, nil
-	case substitution:
-		event := &GameSubstitutionEvent{}
-
-		side, err := checkAndAssertIntFromMap(e, "side")
-		if err != nil {
-			return GameSubstitutionEvent{}, ErrEventParseFailed
-		}
-		event.Side = data.GameTeamSide(side)
-
-		out, err := checkAndAssertStringFromMap(e, "out")
-		if err != nil {
-			return GameSubstitutionEvent{}, ErrEventParseFailed
-		}
-		event.Out = out
-
-		in, err := checkAndAssertStringFromMap(e, "in")
-		if err != nil {
-			return GameSubstitutionEvent{}, ErrEventParseFailed
-		}
-		event.In = in
-
-		err = event.validate()
-		if err != nil {
-			return GameSubstitutionEvent{}, ErrEventParseFailed
-		}
-
-		return event, nil
-	}
-
-	return GameStatEvent{}, nil
-}
-
-// TODO make anonymous event to return that executes and sends problems
-
+// GameStatEvent adds or subtracts a PrimitiveStat for a player. Shots may be located on the court
+// with X and Y, both normalized to the range 0 to 1.
 type GameStatEvent struct {
-	PlayerPin string
-	Stat      stats.PrimitiveStat
-	Action    GameStatAction
+	PlayerPin string              `json:"player_pin"`
+	Stat      stats.PrimitiveStat `json:"stat"`
+	Action    GameStatAction      `json:"action"`
+	X         *float64            `json:"x,omitempty"`
+	Y         *float64            `json:"y,omitempty"`
+	// reversal is set on the event that reverses a voided or undone one, which applies even if the
+	// player has since left the floor.
+	reversal bool
 }
 
 type GameStatAction int
@@ -125,58 +59,185 @@ const (
 )
 
 func (e GameStatEvent) validate() error {
-	if e.Action < 0 || e.Action > 1 {
+	if e.PlayerPin == "" {
+		return ErrEventValidationFailed
+	}
+	// Seconds played are credited from the game clock, not sent by keepers.
+	if !stats.IsPrimitive(e.Stat) || e.Stat == stats.SecondsPlayed {
+		return ErrEventValidationFailed
+	}
+	if e.Action < add || e.Action > subtract {
 		return ErrEventValidationFailed
 	}
+	if e.X != nil || e.Y != nil {
+		if _, ok := shotStats[e.Stat]; !ok {
+			return ErrEventValidationFailed
+		}
+		if e.X == nil || e.Y == nil || *e.X < 0 || *e.X > 1 || *e.Y < 0 || *e.Y > 1 {
+			return ErrEventValidationFailed
+		}
+	}
 	return nil
 }
 
-func (e GameStatEvent) generateClientMessage(h *Hub) ([]byte, error) {
-	bytes, err := json2.Marshal(h.Stats.GetDto())
+func (e GameStatEvent) eventType() GameEventType {
+	return stat
+}
+
+func (e GameStatEvent) reverse() (GameEvent, error) {
+	reversed := e
+	switch e.Action {
+	case add:
+		reversed.Action = subtract
+	case subtract:
+		reversed.Action = add
+	}
+	reversed.reversal = true
+	return reversed, nil
+}
+
+func (e GameStatEvent) execute(h *Hub) error {
+	err := e.check(h, 0)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	fmt.Printf("\nOUTPUT: %s\n", string(bytes))
+	homeBefore, awayBefore := h.Stats.GetScore()
+	e.apply(h)
 
-	return bytes, nil
+	h.statsDelta(statChange{e.PlayerPin, e.Stat})
+	h.scored(homeBefore, awayBefore)
+	if e.Stat == stats.Foul {
+		h.sendFouls()
+	}
+	return nil
 }
 
-func (e GameStatEvent) execute(h *Hub) {
-	if !h.Lineups.isActive(e.PlayerPin) {
-		return
+// check returns an error if the event cannot be applied after pending other changes to the same
+// player's PrimitiveStat.
+func (e GameStatEvent) check(h *Hub, pending int) error {
+	if e.reversal {
+		if _, _, ok := h.Lineups.find(e.PlayerPin); !ok {
+			return ErrPlayerNotActive
+		}
+	} else if !h.Lineups.isActive(e.PlayerPin) {
+		return ErrPlayerNotActive
+	}
+	if e.Action == subtract && h.Stats.GetPrimitive(e.PlayerPin, e.Stat)+pending <= 0 {
+		return ErrStatBelowZero
 	}
+	return nil
+}
+
+// apply changes the player's PrimitiveStat, counting the foul if it is one, without sending
+// anything to keepers or watchers.
+func (e GameStatEvent) apply(h *Hub) {
 	switch e.Action {
 	case add:
 		h.Stats.Add(e.PlayerPin, e.Stat, 1)
 	case subtract:
 		h.Stats.Add(e.PlayerPin, e.Stat, -1)
 	}
-
-	message, err := e.generateClientMessage(h)
-	if err != nil {
-		return
+	if e.Stat == stats.Foul {
+		h.foul(e.PlayerPin, e.Action)
 	}
-
-	h.ToAllWatchers(message)
 }
 
+// GameClockEvent sends a clock.Control to the game clock. SetClock takes a Value of "MM:SS",
+// "MM:SS.t" or "SS.t", and SetPeriod a Value of the period number. Value is ignored by other
+// actions.
 type GameClockEvent struct {
-	Action clock.Control
-	Value  *string
+	Action clock.Control `json:"action"`
+	Value  *string       `json:"value,omitempty"`
 }
 
 func (e GameClockEvent) validate() error {
+	if e.Action < clock.Play || e.Action > clock.EndBreak {
+		return ErrEventValidationFailed
+	}
+	switch e.Action {
+	case clock.SetClock:
+		if e.Value == nil {
+			return ErrEventValidationFailed
+		}
+		_, err := clock.Duration(*e.Value).ToDuration()
+		if err != nil {
+			return ErrEventValidationFailed
+		}
+	case clock.SetPeriod:
+		if e.Value == nil {
+			return ErrEventValidationFailed
+		}
+		period, err := strconv.ParseInt(*e.Value, 10, 64)
+		if err != nil || period <= 0 {
+			return ErrEventValidationFailed
+		}
+	}
 	return nil
 }
 
-func (e GameClockEvent) execute(h *Hub) {
-	h.Clock.Controller <- e.Action
+func (e GameClockEvent) eventType() GameEventType {
+	return gameClock
+}
+
+func (e GameClockEvent) reverse() (GameEvent, error) {
+	reversed := e
+	switch e.Action {
+	case clock.AddMin:
+		reversed.Action = clock.SubtractMin
+	case clock.SubtractMin:
+		reversed.Action = clock.AddMin
+	case clock.AddSec:
+		reversed.Action = clock.SubtractSec
+	case clock.SubtractSec:
+		reversed.Action = clock.AddSec
+	case clock.AddPeriod:
+		reversed.Action = clock.SubtractPeriod
+	case clock.SubtractPeriod:
+		reversed.Action = clock.AddPeriod
+	default:
+		return nil, ErrEventIrreversible
+	}
+	return reversed, nil
+}
+
+func (e GameClockEvent) execute(h *Hub) error {
+	if h.replaying {
+		h.Clock.Replay(e.Action, e.value())
+		switch e.Action {
+		case clock.Play:
+			h.Minutes.start(h.Clock.Remaining())
+		case clock.Pause, clock.CallTimeoutHome, clock.CallTimeoutAway, clock.CallShortTimeoutHome,
+			clock.CallShortTimeoutAway:
+			h.Minutes.stop(h, h.Clock.Remaining())
+		}
+		return nil
+	}
+
+	at, err := h.Clock.Apply(e.Action, e.value())
+	if errors.Is(err, clock.ErrClockRunning) {
+		return ErrClockRunning
+	}
+	if err != nil {
+		return err
+	}
+	// The control is logged at the clock position it was applied from, so replaying it from
+	// there applies it exactly once.
+	h.at = at
+	return nil
+}
+
+// value returns the event's Value, or "" for controls that take none.
+func (e GameClockEvent) value() string {
+	if e.Value == nil {
+		return ""
+	}
+	return *e.Value
 }
 
 type GameSubstitutionEvent struct {
-	Side data.GameTeamSide
-	In   string
-	Out  string
+	Side data.GameTeamSide `json:"side"`
+	In   string            `json:"in"`
+	Out  string            `json:"out"`
 }
 
 func (e GameSubstitutionEvent) validate() error {
@@ -186,20 +247,36 @@ func (e GameSubstitutionEvent) validate() error {
 	return nil
 }
 
-func (e GameSubstitutionEvent) execute(h *Hub) {
-	if h.Clock.GetState() == clock.StatePlaying {
-		return
+func (e GameSubstitutionEvent) eventType() GameEventType {
+	return substitution
+}
+
+func (e GameSubstitutionEvent) reverse() (GameEvent, error) {
+	reversed := e
+	reversed.In, reversed.Out = e.Out, e.In
+	return reversed, nil
+}
+
+func (e GameSubstitutionEvent) execute(h *Hub) error {
+	if h.Clock.GetState() == clock.StatePlaying && h.Clock.GetMode() == clock.ModeStop {
+		return ErrClockRunning
 	}
 
-	h.Lineups.substitution(e.Side, e.Out, e.In)
-	msg := h.toByteArr(envelope{
+	// Time played so far is credited to the players on the floor before they change.
+	h.Minutes.flush(h, h.at.Remaining)
+	err := h.Lineups.substitution(e.Side, e.Out, e.In)
+	if err != nil {
+		return err
+	}
+	msg := h.message(msgLineups, envelope{
 		"active": h.Lineups.getActive(),
 		"bench":  h.Lineups.getBench(),
-		"Dnp":    h.Lineups.getDnp(),
+		"dnp":    h.Lineups.getDnp(),
 		"subs": map[string]string{
 			"out": e.Out,
 			"in":  e.In,
 		},
 	})
 	h.ToAllKeepers(msg)
+	return nil
 }
@@ -0,0 +1,158 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/data"
+	"slices"
+)
+
+// foulTracker counts the fouls of each player in a game, and of each team in each period.
+type foulTracker struct {
+	team   map[int64]map[data.GameTeamSide]int
+	player map[string][]int64
+}
+
+func newFoulTracker() *foulTracker {
+	return &foulTracker{
+		team:   make(map[int64]map[data.GameTeamSide]int),
+		player: make(map[string][]int64),
+	}
+}
+
+// add counts a foul by playerPin of side in period.
+func (ft *foulTracker) add(playerPin string, side data.GameTeamSide, period int64) {
+	if _, ok := ft.team[period]; !ok {
+		ft.team[period] = make(map[data.GameTeamSide]int)
+	}
+	ft.team[period][side]++
+	ft.player[playerPin] = append(ft.player[playerPin], period)
+}
+
+// remove takes back a foul by playerPin of side counted in period, if the player has one.
+func (ft *foulTracker) remove(playerPin string, side data.GameTeamSide, period int64) {
+	periods := ft.player[playerPin]
+	for i := len(periods) - 1; i >= 0; i-- {
+		if periods[i] == period {
+			ft.player[playerPin] = slices.Delete(periods, i, i+1)
+			ft.team[period][side]--
+			return
+		}
+	}
+}
+
+// lastPeriod returns the period of the most recent foul by playerPin, or false if they have none.
+func (ft *foulTracker) lastPeriod(playerPin string) (int64, bool) {
+	periods := ft.player[playerPin]
+	if len(periods) == 0 {
+		return 0, false
+	}
+	return periods[len(periods)-1], true
+}
+
+// teamFouls returns the fouls of side in period.
+func (ft *foulTracker) teamFouls(side data.GameTeamSide, period int64) int {
+	return ft.team[period][side]
+}
+
+// playerFouls returns the fouls of playerPin in the game.
+func (ft *foulTracker) playerFouls(playerPin string) int {
+	return len(ft.player[playerPin])
+}
+
+type bonusState string
+
+const (
+	bonusNone   bonusState = "none"
+	bonusSingle bonusState = "bonus"
+	bonusDouble bonusState = "double_bonus"
+)
+
+// bonus returns the bonus state of a team whose opponents have committed opponentFouls fouls in
+// the period.
+func (h *Hub) bonus(opponentFouls int) bonusState {
+	switch {
+	case h.Game.DoubleBonusFouls > 0 && int64(opponentFouls) >= h.Game.DoubleBonusFouls:
+		return bonusDouble
+	case h.Game.BonusFouls > 0 && int64(opponentFouls) >= h.Game.BonusFouls:
+		return bonusSingle
+	default:
+		return bonusNone
+	}
+}
+
+// foul counts a foul by playerPin that was added or removed, and fouls the player out once they
+// reach the game's foul limit. A voided or restored foul is counted in the period it was committed
+// in, and any other removed foul is taken from the player's most recent.
+func (h *Hub) foul(playerPin string, action GameStatAction) {
+	_, side, ok := h.Lineups.find(playerPin)
+	if !ok {
+		return
+	}
+	period := h.Clock.GetPeriod()
+	if h.correcting != nil {
+		period = h.correcting.period
+	}
+	switch action {
+	case add:
+		h.Fouls.add(playerPin, side, period)
+	case subtract:
+		if h.correcting == nil {
+			period, ok = h.Fouls.lastPeriod(playerPin)
+			if !ok {
+				return
+			}
+		}
+		h.Fouls.remove(playerPin, side, period)
+	}
+
+	limit := h.Game.FoulLimit
+	h.Lineups.setFouledOut(playerPin, limit > 0 && int64(h.Fouls.playerFouls(playerPin)) >= limit)
+}
+
+// sendFouls sends keepers and watchers the foul counts.
+func (h *Hub) sendFouls() {
+	msg := h.message(msgFouls, h.foulsSummary())
+	h.ToAllKeepers(msg)
+	h.ToAllWatchers(msg)
+}
+
+// foulsSummary returns the team fouls and bonus state of each side in the current period, the
+// fouls of each player who has fouled, and the players who have fouled out.
+func (h *Hub) foulsSummary() envelope {
+	period := h.Clock.GetPeriod()
+	homeFouls := h.Fouls.teamFouls(data.TeamHome, period)
+	awayFouls := h.Fouls.teamFouls(data.TeamAway, period)
+
+	players := make(map[string]int)
+	for pin := range h.Fouls.player {
+		if n := h.Fouls.playerFouls(pin); n > 0 {
+			players[pin] = n
+		}
+	}
+
+	return envelope{
+		"period":     period,
+		"home":       envelope{"fouls": homeFouls, "bonus": h.bonus(awayFouls)},
+		"away":       envelope{"fouls": awayFouls, "bonus": h.bonus(homeFouls)},
+		"players":    players,
+		"fouled_out": h.Lineups.getFouledOut(),
+	}
+}
+
+// setFouledOut flags or clears playerPin as fouled out. A fouled out player cannot be substituted
+// back in.
+func (lm *lineupManager) setFouledOut(playerPin string, fouledOut bool) {
+	if fouledOut {
+		lm.fouledOut[playerPin] = true
+	} else {
+		delete(lm.fouledOut, playerPin)
+	}
+}
+
+func (lm *lineupManager) getFouledOut() []string {
+	pins := make([]string, 0, len(lm.fouledOut))
+	for pin := range lm.fouledOut {
+		pins = append(pins, pin)
+	}
+	slices.Sort(pins)
+	return pins
+}
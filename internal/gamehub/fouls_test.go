@@ -0,0 +1,69 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"testing"
+)
+
+func TestBonus(t *testing.T) {
+	tests := []struct {
+		name        string
+		bonus       int64
+		doubleBonus int64
+		fouls       int
+		state       bonusState
+	}{
+		{name: "None", bonus: 7, doubleBonus: 10, fouls: 6, state: bonusNone},
+		{name: "Bonus", bonus: 7, doubleBonus: 10, fouls: 7, state: bonusSingle},
+		{name: "Double Bonus", bonus: 7, doubleBonus: 10, fouls: 12, state: bonusDouble},
+		{name: "No Bonus Rule", fouls: 20, state: bonusNone},
+		{name: "Double Bonus Only", doubleBonus: 5, fouls: 5, state: bonusDouble},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Hub{Game: &data.Game{BonusFouls: tt.bonus, DoubleBonusFouls: tt.doubleBonus}}
+			assert.Equal(t, h.bonus(tt.fouls), tt.state)
+		})
+	}
+}
+
+func TestFoulTracker(t *testing.T) {
+	ft := newFoulTracker()
+	ft.add("h1", data.TeamHome, 1)
+	ft.add("h1", data.TeamHome, 2)
+	ft.add("h2", data.TeamHome, 2)
+	assert.Equal(t, ft.teamFouls(data.TeamHome, 1), 1)
+	assert.Equal(t, ft.teamFouls(data.TeamHome, 2), 2)
+	assert.Equal(t, ft.teamFouls(data.TeamAway, 2), 0)
+	assert.Equal(t, ft.playerFouls("h1"), 2)
+
+	ft.remove("h1", data.TeamHome, 1)
+	assert.Equal(t, ft.teamFouls(data.TeamHome, 1), 0)
+	assert.Equal(t, ft.teamFouls(data.TeamHome, 2), 2)
+	assert.Equal(t, ft.playerFouls("h1"), 1)
+
+	// A player with no foul in the period has nothing to take back.
+	ft.remove("h2", data.TeamHome, 1)
+	assert.Equal(t, ft.playerFouls("h2"), 1)
+	assert.Equal(t, ft.teamFouls(data.TeamHome, 2), 2)
+
+	t.Run("Voided In A Later Period", func(t *testing.T) {
+		h := newTestHub(t)
+
+		id, err := execute(h, statEvent("h1", stats.Foul))
+		assert.NilError(t, err)
+		err = h.Clock.SetPeriod(2)
+		assert.NilError(t, err)
+		_, err = execute(h, statEvent("h1", stats.Foul))
+		assert.NilError(t, err)
+
+		_, err = execute(h, &GameVoidEvent{EventID: id})
+		assert.NilError(t, err)
+		assert.Equal(t, h.Fouls.teamFouls(data.TeamHome, 1), 0)
+		assert.Equal(t, h.Fouls.teamFouls(data.TeamHome, 2), 1)
+		assert.Equal(t, h.Fouls.playerFouls("h1"), 1)
+	})
+}
@@ -3,153 +3,484 @@ package gamehub
 import (
 	"ScoreTableApi/internal/clock"
 	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/jsonlog"
 	"ScoreTableApi/internal/stats"
-	json2 "encoding/json"
 	"errors"
-	"fmt"
 	"github.com/gorilla/websocket"
 	"net/http"
-	"slices"
+	"sync"
+	"time"
 )
 
 var (
 	ErrKeeperNotAuthorized = errors.New("Keeper not authorized")
+	ErrKeeperRoleForbidden = errors.New("keeper role does not allow this event")
 )
 
+// Hub runs a live game. Its state is owned by the Run goroutine, except for its connections and
+// AllowedKeepers, which are guarded by mu.
 type Hub struct {
-	AllowedKeepers []int64
+	AllowedKeepers map[int64]data.KeeperRole
 	Game           *data.Game
 	Stats          *stats.GameStatline
 	Clock          *clock.GameClock
-	//Plays          *PlayEngine
-	//Model for saving stats
-	Lineups  *lineupManager
-	keepers  map[int64]*Keeper
-	Watchers map[*Watcher]bool
-	Events   chan GameEvent
-	Errors   chan error
+	Plays          *PlayEngine
+	Shots          *ShotChart
+	Fouls          *foulTracker
+	Minutes        *minutesTracker
+	LineupStats    *lineupStatsTracker
+	Lineups        *lineupManager
+	keepers        map[int64]*Keeper
+	Watchers       map[*Watcher]bool
+	idleSince      time.Time
+	mu             sync.Mutex
+	events         chan keeperEvent
+	keeperJoins    chan *Keeper
+	joins          chan *Watcher
+	resyncs        chan *Watcher
+	backlog        backlog
+	history        *eventHistory
+	at             clock.Position
+	correcting     *historyEntry
+	replaying      bool
+	ended          bool
+	gamePoint      []data.GameTeamSide
+	winner         *data.GameTeamSide
+	quit           chan struct{}
+	quitOnce       sync.Once
+	done           chan struct{}
+	stopped        chan struct{}
+	seq            int64
+	statsSeq       int64
+	recorder       chan *data.GameEvent
+	recorded       chan struct{}
+	models         *data.Models
+	logger         *jsonlog.Logger
 }
 
+// JoinKeeper upgrades the request to a websocket connection for userID, who must be one of the
+// hub's AllowedKeepers, and sends the keeper the current state of the game. A keeper that is
+// already connected as userID is disconnected.
 func (h *Hub) JoinKeeper(userID int64, w http.ResponseWriter, r *http.Request) error {
+	role, ok := h.keeperRole(userID)
+	if !ok {
+		return ErrKeeperNotAuthorized
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		h.Errors <- err
+		return err
 	}
 
-	k := Keeper{
+	k := &Keeper{
 		Hub:     h,
 		Conn:    conn,
 		UserID:  userID,
-		Receive: make(chan []byte),
+		Role:    role,
+		Receive: make(chan []byte, keeperBufferSize),
 		Close:   make(chan bool),
 	}
-	if !slices.Contains(h.AllowedKeepers, k.UserID) {
-		return ErrKeeperNotAuthorized
-	}
 
-	h.keepers[k.UserID] = &k
+	select {
+	case h.keeperJoins <- k:
+	case <-h.done:
+		_ = conn.Close()
+		return nil
+	}
 	go k.ReadEvents()
 	go k.WriteEvents()
 
-	welcomeData := h.toByteArr(envelope{
-		"stats":    h.Stats.GetDto(),
-		"clock":    h.Clock.Get(),
-		"period":   h.Clock.GetPeriod(),
-		"game":     h.Game,
-		"timeouts": h.Clock.GetTimeouts(),
-		"active":   h.Lineups.getActive(),
-		"bench":    h.Lineups.getBench(),
-		"dnp":      h.Lineups.getDnp(),
-	})
+	return nil
+}
 
-	k.Receive <- welcomeData
+// addKeeper connects k to the hub and sends it a snapshot of the game.
+func (h *Hub) addKeeper(k *Keeper) {
+	h.mu.Lock()
+	if old, ok := h.keepers[k.UserID]; ok {
+		h.dropKeeper(old)
+	}
+	h.keepers[k.UserID] = k
+	h.mu.Unlock()
 
-	return nil
+	h.ToKeeper(k, h.message(msgSnapshot, envelope{
+		"stats":      h.Stats.GetDto(),
+		"stats_seq":  h.statsSeq,
+		"clock":      h.Clock.Get(),
+		"shot_clock": h.Clock.GetShotClock(),
+		"break":      h.Clock.GetBreak(),
+		"clock_mode": h.Clock.GetMode(),
+		"period":     h.Clock.GetPeriod(),
+		"game":       h.Game,
+		"timeouts":   h.Clock.GetTimeouts(),
+		"active":     h.Lineups.getActive(),
+		"bench":      h.Lineups.getBench(),
+		"dnp":        h.Lineups.getDnp(),
+		"fouls":      h.foulsSummary(),
+		"lineups":    h.LineupStats.report(),
+	}))
 }
 
+// LeaveKeeper disconnects the keeper connected as userID, if there is one.
 func (h *Hub) LeaveKeeper(userID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	if k, ok := h.keepers[userID]; ok {
-		delete(h.keepers, userID)
-		close(k.Receive)
-		close(k.Close)
-		k.Conn.Close()
+		h.dropKeeper(k)
+	}
+}
+
+// removeKeeper disconnects k, unless it has already been replaced by a newer connection.
+func (h *Hub) removeKeeper(k *Keeper) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.keepers[k.UserID] == k {
+		h.dropKeeper(k)
+	}
+}
+
+// dropKeeper disconnects k. h.mu must be held.
+func (h *Hub) dropKeeper(k *Keeper) {
+	delete(h.keepers, k.UserID)
+	close(k.Receive)
+	close(k.Close)
+	_ = k.Conn.Close()
+	if len(h.keepers) == 0 {
+		h.idleSince = time.Now()
+	}
+}
+
+// keeperRole returns the role userID may keep the hub with, if they are allowed to keep it.
+func (h *Hub) keeperRole(userID int64) (data.KeeperRole, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	role, ok := h.AllowedKeepers[userID]
+	return role, ok
+}
+
+// idleFor returns how long the hub has had no keepers connected.
+func (h *Hub) idleFor() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.keepers) > 0 {
+		return 0
 	}
+	return time.Since(h.idleSince)
+}
+
+// touch resets how long the hub has been idle, so it is not reaped before a keeper connects.
+func (h *Hub) touch() {
+	h.mu.Lock()
+	h.idleSince = time.Now()
+	h.mu.Unlock()
 }
 
 // TODO make JoinWatcher receive w and r instead of conn
 
 func (h *Hub) JoinWatcher(conn *websocket.Conn) *Watcher {
-	w := newWatcher(h, conn)
-	h.Watchers[w] = true
+	w := newWatcher(h, conn, 0)
+	select {
+	case h.joins <- w:
+	case <-h.done:
+		_ = conn.Close()
+		return w
+	}
+	go w.ReadEvents()
 	go w.WriteEvents()
-
-	welcomeData := h.toByteArr(envelope{
-		"stats":  h.Stats.GetDto(),
-		"clock":  h.Clock.Get(),
-		"period": h.Clock.GetPeriod(),
-		"game":   h.Game,
-	})
-	w.Receive <- welcomeData
 	return w
 }
 
+// addWatcher subscribes w to the hub's broadcasts. A watcher resuming from a frame that is still
+// in the backlog is sent the frames it missed, and any other watcher is sent a snapshot.
+func (h *Hub) addWatcher(w *Watcher) {
+	frames, ok := h.backlog.since(w.lastSeq)
+	if w.lastSeq == 0 || !ok {
+		snapshot := h.message(msgSnapshot, envelope{
+			"stats":      h.Stats.GetDto(),
+			"stats_seq":  h.statsSeq,
+			"clock":      h.Clock.Get(),
+			"shot_clock": h.Clock.GetShotClock(),
+			"break":      h.Clock.GetBreak(),
+			"clock_mode": h.Clock.GetMode(),
+			"period":     h.Clock.GetPeriod(),
+			"game":       h.Game,
+			"fouls":      h.foulsSummary(),
+			"lineups":    h.LineupStats.report(),
+		})
+		frames = []frame{{seq: h.backlog.seq, msg: snapshot}}
+	}
+	for _, f := range frames {
+		w.Receive <- f
+	}
+
+	h.mu.Lock()
+	h.Watchers[w] = true
+	h.mu.Unlock()
+}
+
 func (h *Hub) LeaveWatcher(w *Watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	if _, ok := h.Watchers[w]; ok {
-		delete(h.Watchers, w)
-		close(w.Receive)
-		close(w.Error)
-		w.Conn.Close()
+		h.dropWatcher(w)
+	}
+}
+
+// dropWatcher disconnects w. h.mu must be held.
+func (h *Hub) dropWatcher(w *Watcher) {
+	delete(h.Watchers, w)
+	close(w.Receive)
+	if w.Conn != nil {
+		_ = w.Conn.Close()
 	}
 }
 
 // TODO pass in blueprint on create statline, send out list of possible stats to Keeper and client
 
+// Run handles keeper events, clock ticks and connections until the game ends or the hub is stopped.
 func (h *Hub) Run() {
 	for {
 		select {
-		case event := <-h.Events:
-			fmt.Printf("event from hub: %v", event)
-			event.execute(h)
+		case event := <-h.events:
+			if event.err != nil {
+				h.reject(event.keeper, event.id, event.err)
+				continue
+			}
+			err := h.authorize(event)
+			if err != nil {
+				h.reject(event.keeper, event.id, err)
+				continue
+			}
+			h.at = h.Clock.Position()
+			err = event.execute(h)
+			if err != nil {
+				h.reject(event.keeper, event.id, err)
+				continue
+			}
+			id := h.record(event.keeper.UserID, event.GameEvent)
+			h.remember(id, event.GameEvent)
+			h.ack(event.keeper, event.id, id)
+			if !h.ended {
+				h.checkTarget()
+			}
+			if h.ended {
+				h.finish()
+				return
+			}
+		case k := <-h.keeperJoins:
+			h.addKeeper(k)
+		case w := <-h.joins:
+			h.addWatcher(w)
+		case w := <-h.resyncs:
+			h.ToWatcher(w, h.message(msgStats, envelope{
+				"seq":   h.statsSeq,
+				"stats": h.Stats.GetDto(),
+			}))
 		case tick := <-h.Clock.C:
-			fmt.Printf("%+v\n", tick)
-			msg := h.toByteArr(envelope{"clock": tick.Value})
+			msg := h.message(msgClock, envelope{
+				"event": tick.EventType,
+				"value": tick.Value,
+			})
 			h.ToAllKeepers(msg)
 			h.ToAllWatchers(msg)
-		case err := <-h.Errors:
-			fmt.Printf("\nHUB ERROR: %s\n", err.Error())
-			for _, k := range h.keepers {
-				k.Close <- true
+			switch tick.EventType {
+			case clock.Transport:
+				// Pausing the clock sends a Transport event with no value.
+				if tick.Value != "" {
+					h.Minutes.start(tick.Remaining)
+				} else {
+					h.Minutes.stop(h, tick.Remaining)
+				}
+			case clock.Timeout, clock.Done:
+				h.Minutes.stop(h, tick.Remaining)
 			}
-			for w := range h.Watchers {
-				w.Error <- err
+			if tick.EventType == clock.PeriodSet {
+				// Team fouls are counted per period.
+				h.sendFouls()
 			}
+		case <-h.quit:
+			h.stop()
+			return
 		}
 	}
 }
 
+// Stop stops a running hub without finishing its game. Events already executed are still saved
+// to the game's event log, so the game can be started again where it left off.
+func (h *Hub) Stop() {
+	h.quitOnce.Do(func() {
+		close(h.quit)
+	})
+}
+
+// stop closes the clock, the event log and every connection, and waits until queued events are
+// saved. Connections are closed by closing their send channels, so each connection's writer
+// sends any queued messages before closing the connection.
+func (h *Hub) stop() {
+	h.Clock.Close()
+	close(h.recorder)
+	close(h.done)
+
+	h.mu.Lock()
+	for id, k := range h.keepers {
+		delete(h.keepers, id)
+		close(k.Receive)
+	}
+	for w := range h.Watchers {
+		delete(h.Watchers, w)
+		close(w.Receive)
+	}
+	h.mu.Unlock()
+
+	<-h.recorded
+}
+
+// authorize checks that the role of the keeper that sent an event allows it. Corrections are
+// checked against the event they correct, and an undo is resolved to the last event the keeper's
+// role allows.
+func (h *Hub) authorize(ke keeperEvent) error {
+	var target int64
+	switch e := ke.GameEvent.(type) {
+	case *GameUndoEvent:
+		// Keepers cannot choose what to undo or redo. EventID is only set in the event log.
+		if e.EventID != 0 {
+			return ErrEventValidationFailed
+		}
+		id, err := h.history.last(func(event GameEvent) bool {
+			return ke.keeper.allows(event.eventType())
+		})
+		if err != nil {
+			return err
+		}
+		e.EventID = id
+		return nil
+	case *GameRedoEvent:
+		if e.EventID != 0 {
+			return ErrEventValidationFailed
+		}
+		if len(h.history.redo) == 0 {
+			return ErrNothingToRedo
+		}
+		target = h.history.redo[len(h.history.redo)-1]
+		e.EventID = target
+	case *GameVoidEvent:
+		target = e.EventID
+	default:
+		if !ke.keeper.allows(ke.eventType()) {
+			return ErrKeeperRoleForbidden
+		}
+		return nil
+	}
+
+	entry, ok := h.history.entries[target]
+	if !ok {
+		return ErrEventNotFound
+	}
+	if !ke.keeper.allows(entry.event.eventType()) {
+		return ErrKeeperRoleForbidden
+	}
+	return nil
+}
+
+// remember adds an executed event to the hub's history, shot chart and play-by-play.
+func (h *Hub) remember(id int64, e GameEvent) {
+	h.history.add(id, e, h.at.Period, h.Lineups.getActive())
+	h.Shots.record(h, id, e)
+	play := h.Plays.record(h, id, e)
+	if play != nil {
+		msg := h.message(msgPlay, play)
+		h.ToAllKeepers(msg)
+		h.ToAllWatchers(msg)
+	}
+}
+
+// statChange is a change to one player's PrimitiveStat.
+type statChange struct {
+	playerPin string
+	stat      stats.PrimitiveStat
+}
+
+// statsDelta broadcasts to watchers, as one message, the stats that depend on the changed
+// PrimitiveStat's, numbered so watchers can tell when they have missed an update and need to
+// resync.
+func (h *Hub) statsDelta(changes ...statChange) {
+	if len(changes) == 0 {
+		return
+	}
+	delta := stats.GameStatlineDto{}
+	for _, c := range changes {
+		delta.Merge(h.Stats.GetDtoFromPrimitive(c.playerPin, c.stat))
+	}
+
+	h.statsSeq++
+	h.ToAllWatchers(h.message(msgStatsDelta, envelope{
+		"seq":   h.statsSeq,
+		"stats": delta,
+	}))
+}
+
+// ToAllWatchers broadcasts msg to every watcher as the next frame of the hub's backlog.
 func (h *Hub) ToAllWatchers(msg []byte) {
+	if h.replaying {
+		return
+	}
+	f := h.backlog.append(msg)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	for watcher := range h.Watchers {
 		select {
-		case watcher.Receive <- msg:
+		case watcher.Receive <- f:
 		default:
-			h.LeaveWatcher(watcher)
+			h.dropWatcher(watcher)
 		}
 	}
 }
 
+// ToWatcher sends msg to w alone, numbered as the hub's latest frame.
+func (h *Hub) ToWatcher(w *Watcher, msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.Watchers[w]; !ok {
+		return
+	}
+	select {
+	case w.Receive <- frame{seq: h.backlog.seq, msg: msg}:
+	default:
+		h.dropWatcher(w)
+	}
+}
+
+func (h *Hub) ToKeeper(k *Keeper, msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.keepers[k.UserID] != k {
+		return
+	}
+	select {
+	case k.Receive <- msg:
+	default:
+		h.dropKeeper(k)
+	}
+}
+
 func (h *Hub) ToAllKeepers(msg []byte) {
-	for i, k := range h.keepers {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, k := range h.keepers {
 		select {
 		case k.Receive <- msg:
 		default:
-			h.LeaveKeeper(i)
+			h.dropKeeper(k)
 		}
 	}
 }
 
-func (h *Hub) toByteArr(v envelope) []byte {
-	bytes, _ := json2.Marshal(v)
-	return bytes
-}
-
 type envelope map[string]any
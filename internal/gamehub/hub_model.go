@@ -3,83 +3,380 @@ package gamehub
 import (
 	"ScoreTableApi/internal/clock"
 	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/jsonlog"
 	"ScoreTableApi/internal/stats"
+	"context"
 	"errors"
 	"github.com/gorilla/websocket"
 	"net/http"
+	"sync"
 	"time"
 )
 
 var (
 	ErrGameNotFound = errors.New("game not found")
 	ErrTwoTeams     = errors.New("game must have two teams to start")
+	ErrGameFinished = errors.New("game has finished")
+	ErrShuttingDown = errors.New("game hubs are shutting down")
 	upgrader        = websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
 	}
 )
 
+// HubModel is the registry of active Hubs by game pin. It is safe for concurrent use.
 type HubModel struct {
-	active map[string]*Hub
-	model  *data.GameModel
+	active   map[string]*Hub
+	mu       sync.RWMutex
+	closed   bool
+	quit     chan struct{}
+	quitOnce sync.Once
+	models   *data.Models
+	logger   *jsonlog.Logger
 }
 
-func NewModel(model *data.GameModel) HubModel {
-	return HubModel{
+func NewModel(models *data.Models, logger *jsonlog.Logger) *HubModel {
+	return &HubModel{
 		active: make(map[string]*Hub),
-		model:  model,
+		quit:   make(chan struct{}),
+		models: models,
+		logger: logger,
 	}
 }
 
-func (m *HubModel) StartGame(g *data.Game) (*Hub, error) {
+// Get returns the active Hub of the game with pin.
+func (m *HubModel) Get(pin string) (*Hub, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	h, ok := m.active[pin]
+	return h, ok
+}
+
+// List returns every active Hub.
+func (m *HubModel) List() []*Hub {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hubs := make([]*Hub, 0, len(m.active))
+	for _, h := range m.active {
+		hubs = append(hubs, h)
+	}
+	return hubs
+}
+
+// StartGame marks a game as in progress and starts a Hub for it on behalf of userID, who must be
+// the game's owner or one of its keepers. If the game already has an active Hub, that Hub is
+// returned so keepers can reconnect, and if the game was already in progress its Hub is rebuilt
+// from the game's event log.
+func (m *HubModel) StartGame(g *data.Game, userID int64) (*Hub, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for {
+		if m.closed {
+			return nil, ErrShuttingDown
+		}
+		h, ok := m.active[g.PinID.Pin]
+		if !ok {
+			break
+		}
+		select {
+		case <-h.done:
+			// The hub is stopping. Wait for its events to be saved before rebuilding it.
+			m.mu.Unlock()
+			<-h.stopped
+			m.mu.Lock()
+		default:
+			if _, ok := h.keeperRole(userID); !ok {
+				return nil, ErrKeeperNotAuthorized
+			}
+			h.touch()
+			return h, nil
+		}
+	}
+
 	err := m.validateGame(g)
 	if err != nil {
 		return nil, err
 	}
 
-	err = m.model.StartGameInDB(g)
+	hub, err := m.newHub(g)
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := hub.AllowedKeepers[userID]; !ok {
+		hub.Clock.Close()
+		return nil, ErrKeeperNotAuthorized
+	}
+
+	if g.Status == data.INPROGRESS {
+		err = m.replay(hub)
+		if err != nil {
+			hub.Clock.Close()
+			return nil, err
+		}
+		if hub.ended {
+			go hub.runRecorder()
+			hub.finish()
+			return nil, ErrGameFinished
+		}
+	} else {
+		err = m.models.Games.StartGameInDB(g)
+		if err != nil {
+			hub.Clock.Close()
+			return nil, err
+		}
+	}
+	m.run(hub)
+
+	return hub, nil
+}
+
+// AllowKeeper lets userID keep the active Hub of the game with pin, if there is one, with role.
+func (m *HubModel) AllowKeeper(pin string, userID int64, role data.KeeperRole) {
+	h, ok := m.Get(pin)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.AllowedKeepers[userID]; ok {
+		return
+	}
+	h.AllowedKeepers[userID] = role
+}
+
+// RevokeKeeper removes userID from the keepers of the active Hub of the game with pin, if there is
+// one, and disconnects them.
+func (m *HubModel) RevokeKeeper(pin string, userID int64) {
+	h, ok := m.Get(pin)
+	if !ok || userID == h.Game.UserID {
+		return
+	}
+
+	h.mu.Lock()
+	delete(h.AllowedKeepers, userID)
+	h.mu.Unlock()
+	h.LeaveKeeper(userID)
+}
+
+// RestoreActive rebuilds a Hub for every game still marked as in progress, by replaying each
+// game's event log. It is called on startup so live games survive a server restart.
+func (m *HubModel) RestoreActive() error {
+	games, err := m.models.Games.GetAllInProgress()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, g := range games {
+		err := m.restore(g)
+		if err != nil {
+			m.logger.PrintError(err, map[string]string{"game": g.PinID.Pin})
+			continue
+		}
+		m.logger.PrintInfo("restored game hub", map[string]string{"game": g.PinID.Pin})
+	}
+
+	return nil
+}
+
+// restore creates a Hub for an in-progress game and replays its event log before running it. A
+// game whose log ends with a GameEndEvent that was not finalized is finished instead.
+func (m *HubModel) restore(g *data.Game) error {
+	hub, err := m.newHub(g)
+	if err != nil {
+		return err
+	}
+
+	err = m.replay(hub)
+	if err != nil {
+		hub.Clock.Close()
+		return err
+	}
+	if hub.ended {
+		go hub.runRecorder()
+		hub.finish()
+		return nil
+	}
+	m.run(hub)
+
+	return nil
+}
+
+// replay rebuilds a new Hub from its game's event log.
+func (m *HubModel) replay(hub *Hub) error {
+	events, err := m.models.GameEvents.GetAllForGame(hub.Game.ID)
+	if err != nil {
+		return err
+	}
+	return hub.replay(events)
+}
+
+// newHub creates a Hub for g with a fresh statline, lineups and clock, that can be kept by the
+// game's owner and invited keepers.
+func (m *HubModel) newHub(g *data.Game) (*Hub, error) {
+	keepers, err := m.models.GameKeepers.GetAllForGame(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	allowedKeepers := map[int64]data.KeeperRole{g.UserID: data.KeeperRoleAll}
+	for _, k := range keepers {
+		allowedKeepers[k.ID] = k.Role
+	}
 
 	hub := &Hub{
-		AllowedKeepers: []int64{g.UserID},
+		AllowedKeepers: allowedKeepers,
 		Game:           g,
 		Stats:          stats.NewGameStatline(g.HomePlayerPins, g.AwayPlayerPins, stats.Simple),
-		//Plays:          &PlayEngine{},
-		Lineups:  newLineupManager(g),
-		keepers:  make(map[int64]*Keeper),
-		Watchers: make(map[*Watcher]bool),
-		Events:   make(chan GameEvent),
-		Errors:   make(chan error),
-	}
-
-	var c *clock.GameClock
-	if g.Type == "timed" {
-		c = clock.NewGameClock(clock.Config{
-			PeriodLength:    time.Duration(*g.PeriodLength),
-			PeriodCount:     *g.PeriodCount,
-			OtDuration:      time.Duration(*g.PeriodLength) / 2,
-			TimeoutsAllowed: 4,
-			TimeoutDuration: 20 * time.Second,
-		})
-	} else {
-		c = clock.NewGameClock(clock.Config{})
+		Plays:          &PlayEngine{},
+		Shots:          &ShotChart{},
+		Fouls:          newFoulTracker(),
+		Minutes:        newMinutesTracker(),
+		LineupStats:    newLineupStatsTracker(),
+		Lineups:        newLineupManager(g),
+		keepers:        make(map[int64]*Keeper),
+		Watchers:       make(map[*Watcher]bool),
+		idleSince:      time.Now(),
+		events:         make(chan keeperEvent),
+		keeperJoins:    make(chan *Keeper),
+		joins:          make(chan *Watcher),
+		resyncs:        make(chan *Watcher),
+		quit:           make(chan struct{}),
+		done:           make(chan struct{}),
+		stopped:        make(chan struct{}),
+		backlog:        backlog{epoch: time.Now().UnixNano()},
+		history:        newEventHistory(),
+		recorder:       make(chan *data.GameEvent, recorderBufferSize),
+		recorded:       make(chan struct{}),
+		models:         m.models,
+		logger:         m.logger,
 	}
-	hub.Clock = c
 
-	m.active[g.PinID.Pin] = hub
-	go hub.Run()
+	cfg := clock.Config{
+		TimeoutsAllowed:      int(g.Timeouts),
+		ShortTimeoutsAllowed: int(g.ShortTimeouts),
+		TimeoutsPerHalf:      g.TimeoutsPer == data.TimeoutsPerHalf,
+	}
+	if g.TimeoutLength != nil {
+		cfg.TimeoutDuration = g.TimeoutLength.Duration()
+	}
+	if g.ShortTimeoutLength != nil {
+		cfg.ShortTimeoutDuration = g.ShortTimeoutLength.Duration()
+	}
+	if g.ShotClockLength != nil {
+		cfg.ShotClockLength = g.ShotClockLength.Duration()
+	}
+	if g.ShotClockShort != nil {
+		cfg.ShotClockShort = g.ShotClockShort.Duration()
+	}
+	if g.Type == data.GameTypeTimed {
+		cfg.PeriodLength = g.PeriodLength.Duration()
+		cfg.PeriodCount = *g.PeriodCount
+		cfg.OtDuration = g.PeriodLength.Duration() / 2
+		if g.OtLength != nil {
+			cfg.OtDuration = g.OtLength.Duration()
+		}
+		if g.BreakLength != nil {
+			cfg.BreakDuration = g.BreakLength.Duration()
+		}
+		if g.HalftimeLength != nil {
+			cfg.HalftimeDuration = g.HalftimeLength.Duration()
+		}
+		if g.OtBreakLength != nil {
+			cfg.OtBreakDuration = g.OtBreakLength.Duration()
+		}
+		cfg.RunningClock = g.RunningClock
+		if g.StopClockUnder != nil {
+			cfg.StopClockUnder = g.StopClockUnder.Duration()
+		}
+		if g.StopClockMargin != nil {
+			cfg.StopClockMargin = int(*g.StopClockMargin)
+		}
+	}
+	hub.Clock = clock.NewGameClock(cfg, clock.SystemTime)
 
 	return hub, nil
 }
 
+// run registers hub as active and starts its goroutines. The hub is removed once its game ends or
+// it is stopped. m.mu must be held.
+func (m *HubModel) run(hub *Hub) {
+	m.active[hub.Game.PinID.Pin] = hub
+	go hub.runRecorder()
+	go func() {
+		hub.Run()
+
+		m.mu.Lock()
+		if m.active[hub.Game.PinID.Pin] == hub {
+			delete(m.active, hub.Game.PinID.Pin)
+		}
+		m.mu.Unlock()
+		close(hub.stopped)
+	}()
+}
+
+// RunReaper stops hubs that have had no keepers connected for longer than idleTimeout, until
+// Shutdown is called. The events of a stopped hub are saved before it is removed, so its game is
+// rebuilt from the event log when it is next started.
+func (m *HubModel) RunReaper(idleTimeout time.Duration) {
+	interval := min(idleTimeout/2, time.Minute)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, h := range m.List() {
+				if h.idleFor() > idleTimeout {
+					m.logger.PrintInfo("stopping idle game hub", map[string]string{
+						"game": h.Game.PinID.Pin,
+					})
+					h.Stop()
+				}
+			}
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// Shutdown stops the reaper and every active Hub, and waits until their events are saved or ctx
+// is done. No games can be started afterwards.
+func (m *HubModel) Shutdown(ctx context.Context) error {
+	m.quitOnce.Do(func() {
+		close(m.quit)
+	})
+
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+
+	hubs := m.List()
+	for _, h := range hubs {
+		h.Stop()
+	}
+	for _, h := range hubs {
+		select {
+		case <-h.stopped:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
 func (m *HubModel) WatcherJoinGame(pin string, wr http.ResponseWriter, r *http.Request) (*Watcher,
 	error) {
-	if _, ok := m.active[pin]; !ok {
+	h, ok := m.Get(pin)
+	if !ok {
 		return nil, ErrGameNotFound
 	}
-	h := m.active[pin]
 
 	conn, err := upgrader.Upgrade(wr, r, nil)
 	if err != nil {
@@ -90,9 +387,87 @@ func (m *HubModel) WatcherJoinGame(pin string, wr http.ResponseWriter, r *http.R
 	return w, nil
 }
 
+// WatcherStreamGame streams the broadcasts of the active Hub of the game with pin to wr as
+// Server-Sent Events. It blocks until the stream ends.
+func (m *HubModel) WatcherStreamGame(pin string, wr http.ResponseWriter, r *http.Request) error {
+	h, ok := m.Get(pin)
+	if !ok {
+		return ErrGameNotFound
+	}
+	return h.StreamWatcher(wr, r)
+}
+
+// GetPlays returns the play-by-play of the game with pin. Plays of a game without an active Hub
+// are rebuilt from its event log.
+func (m *HubModel) GetPlays(pin string) ([]PlayerPlay, error) {
+	h, err := m.getOrReplay(pin)
+	if err != nil {
+		return nil, err
+	}
+	return h.Plays.List(), nil
+}
+
+// GetShots returns the located shots of the game with pin that match filter, and their makes and
+// attempts by zone. Shots of a game without an active Hub are rebuilt from its event log.
+func (m *HubModel) GetShots(pin string, filter ShotFilter) ([]Shot, map[shotZone]ZoneStats, error) {
+	h, err := m.getOrReplay(pin)
+	if err != nil {
+		return nil, nil, err
+	}
+	shots, zones := h.Shots.List(filter)
+	return shots, zones, nil
+}
+
+// GetLineupStats returns the plus/minus of each player and the stats of each team's lineups in the
+// game with pin. The stats of a game without an active Hub are rebuilt from its event log.
+func (m *HubModel) GetLineupStats(pin string) (LineupReport, error) {
+	h, err := m.getOrReplay(pin)
+	if err != nil {
+		return LineupReport{}, err
+	}
+	return h.LineupStats.report(), nil
+}
+
+// getOrReplay returns the active Hub of the game with pin, or a Hub rebuilt from the game's event
+// log that is not run and cannot be joined.
+func (m *HubModel) getOrReplay(pin string) (*Hub, error) {
+	if h, ok := m.Get(pin); ok {
+		return h, nil
+	}
+
+	g, err := m.models.Games.GetByPin(pin)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			return nil, ErrGameNotFound
+		default:
+			return nil, err
+		}
+	}
+	if g.Teams.Home == nil || g.Teams.Away == nil {
+		return nil, ErrTwoTeams
+	}
+
+	h, err := m.newHub(g)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Clock.Close()
+
+	err = m.replay(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
 // TODO validate game before starting
 
 func (m *HubModel) validateGame(game *data.Game) error {
+	if game.Status == data.FINISHED {
+		return ErrGameFinished
+	}
 	if game.HomeTeamPin == nil || game.AwayTeamPin == nil {
 		return ErrTwoTeams
 	}
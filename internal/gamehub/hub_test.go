@@ -0,0 +1,69 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/clock"
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/pins"
+	"ScoreTableApi/internal/stats"
+	"testing"
+	"time"
+)
+
+// newTestHub returns a Hub for a game of two players a side, with home players h1 and h2 and away
+// players a1 and a2 on the floor, and h3 and a3 on the bench. The hub is not run, so tests
+// execute events on it directly.
+func newTestHub(t *testing.T) *Hub {
+	t.Helper()
+	team := func(pin string, side data.GameTeamSide) *data.Team {
+		tm := &data.Team{PinID: pins.Pin{Pin: pin}, Name: pin, Side: side}
+		for i := 1; i <= 3; i++ {
+			pos := i
+			tm.Players = append(tm.Players, &data.Player{
+				PinId:     pins.Pin{Pin: string(pin[0]) + string(rune('0'+i))},
+				FirstName: "First",
+				LastName:  pin,
+				LineupPos: &pos,
+			})
+		}
+		return tm
+	}
+
+	g := &data.Game{TeamSize: 2, Type: data.GameTypeTimed}
+	g.Teams.Home = team("home", data.TeamHome)
+	g.Teams.Away = team("away", data.TeamAway)
+	g.HomePlayerPins = []string{"h1", "h2", "h3"}
+	g.AwayPlayerPins = []string{"a1", "a2", "a3"}
+
+	h := &Hub{
+		Game:        g,
+		Stats:       stats.NewGameStatline(g.HomePlayerPins, g.AwayPlayerPins, stats.Simple),
+		Clock:       clock.NewGameClock(clock.Config{PeriodLength: time.Minute, PeriodCount: 4}, nil),
+		Plays:       &PlayEngine{},
+		Shots:       &ShotChart{},
+		Fouls:       newFoulTracker(),
+		Minutes:     newMinutesTracker(),
+		LineupStats: newLineupStatsTracker(),
+		Lineups:     newLineupManager(g),
+		keepers:     make(map[int64]*Keeper),
+		Watchers:    make(map[*Watcher]bool),
+		history:     newEventHistory(),
+	}
+	t.Cleanup(h.Clock.Close)
+	return h
+}
+
+// execute executes e on h the way Run does, returning e's ID, or its error if it failed.
+func execute(h *Hub, e GameEvent) (int64, error) {
+	h.at = h.Clock.Position()
+	err := e.execute(h)
+	if err != nil {
+		return 0, err
+	}
+	h.seq++
+	h.remember(h.seq, e)
+	return h.seq, nil
+}
+
+func statEvent(playerPin string, stat stats.PrimitiveStat) *GameStatEvent {
+	return &GameStatEvent{PlayerPin: playerPin, Stat: stat, Action: add}
+}
@@ -1,7 +1,7 @@
 package gamehub
 
 import (
-	json2 "encoding/json"
+	"ScoreTableApi/internal/data"
 	"github.com/gorilla/websocket"
 	"log"
 	"time"
@@ -11,17 +11,34 @@ type Keeper struct {
 	Hub     *Hub
 	Conn    *websocket.Conn
 	UserID  int64
+	Role    data.KeeperRole
 	Receive chan []byte
 	Close   chan bool
 }
 
+// allows reports whether the Keeper's role lets it send events of type t.
+func (k *Keeper) allows(t GameEventType) bool {
+	switch k.Role {
+	case data.KeeperRoleAll:
+		return true
+	case data.KeeperRoleStats:
+		return t == stat || t == compound
+	case data.KeeperRoleClock:
+		return t == gameClock
+	case data.KeeperRoleSubstitution:
+		return t == substitution
+	default:
+		return false
+	}
+}
+
 // TODO return close error on game hub and close connections and goroutines when closed
 
 func (k *Keeper) WriteEvents() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
-		k.Hub.LeaveKeeper(k.UserID)
+		k.Hub.removeKeeper(k)
 	}()
 	for {
 		select {
@@ -70,7 +87,7 @@ func (k *Keeper) WriteEvents() {
 
 func (k *Keeper) ReadEvents() {
 	defer func() {
-		k.Hub.LeaveKeeper(k.UserID)
+		k.Hub.removeKeeper(k)
 	}()
 	for {
 		k.Conn.SetReadLimit(maxMessageSize)
@@ -80,26 +97,22 @@ func (k *Keeper) ReadEvents() {
 			return nil
 		})
 
-		var genericEvent GenericEvent
-		_, bytes, err := k.Conn.ReadMessage()
+		_, msg, err := k.Conn.ReadMessage()
 		if err != nil {
-			k.Hub.Errors <- err
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("error: %v", err)
 			}
 			return
 		}
 
-		err = json2.Unmarshal(bytes, &genericEvent)
-		if err != nil {
-			k.Hub.Errors <- err
-		}
-
-		event, err := genericEvent.parseEvent()
-		if err != nil {
-			k.Hub.Errors <- err
+		// Messages that cannot be parsed are still sent to the hub, so it can reply to the keeper
+		// in order with its other messages.
+		event := parseMessage(msg)
+		event.keeper = k
+		select {
+		case k.Hub.events <- event:
+		case <-k.Hub.done:
+			return
 		}
-
-		k.Hub.Events <- event
 	}
 }
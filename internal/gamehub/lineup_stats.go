@@ -0,0 +1,159 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/data"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lineupStatsTracker keeps each player's plus/minus, and the points for and against and time
+// played of each combination of players a team has had on the floor.
+type lineupStatsTracker struct {
+	plusMinus map[string]int
+	combos    map[data.GameTeamSide]map[string]*LineupStats
+	mu        sync.RWMutex
+}
+
+// LineupStats are the stats of one combination of players on the floor for a team.
+type LineupStats struct {
+	Players       []string `json:"players"`
+	PointsFor     int      `json:"points_for"`
+	PointsAgainst int      `json:"points_against"`
+	PlusMinus     int      `json:"plus_minus"`
+	Seconds       int      `json:"seconds"`
+	played        time.Duration
+}
+
+// LineupReport is the plus/minus of every player who has been on the floor, and the stats of each
+// team's lineups, longest played first.
+type LineupReport struct {
+	PlusMinus map[string]int `json:"plus_minus"`
+	Home      []LineupStats  `json:"home"`
+	Away      []LineupStats  `json:"away"`
+}
+
+func newLineupStatsTracker() *lineupStatsTracker {
+	return &lineupStatsTracker{
+		plusMinus: make(map[string]int),
+		combos: map[data.GameTeamSide]map[string]*LineupStats{
+			data.TeamHome: make(map[string]*LineupStats),
+			data.TeamAway: make(map[string]*LineupStats),
+		},
+	}
+}
+
+// combo returns the stats of the lineup lnp of side, adding it if it has not been on the floor.
+// lt.mu must be held.
+func (lt *lineupStatsTracker) combo(side data.GameTeamSide, lnp lineup) *LineupStats {
+	pins := make([]string, 0, len(lnp))
+	for _, p := range lnp {
+		pins = append(pins, p.PinId.Pin)
+	}
+	slices.Sort(pins)
+
+	key := strings.Join(pins, ",")
+	ls, ok := lt.combos[side][key]
+	if !ok {
+		ls = &LineupStats{Players: pins}
+		lt.combos[side][key] = ls
+	}
+	return ls
+}
+
+// scored counts points scored by side against the players on the floor. Points are negative when
+// a basket is taken back.
+func (lt *lineupStatsTracker) scored(active map[data.GameTeamSide]lineup, side data.GameTeamSide,
+	points int) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	for s, lnp := range active {
+		diff := points
+		ls := lt.combo(s, lnp)
+		if s == side {
+			ls.PointsFor += points
+		} else {
+			ls.PointsAgainst += points
+			diff = -points
+		}
+		ls.PlusMinus += diff
+		for _, p := range lnp {
+			lt.plusMinus[p.PinId.Pin] += diff
+		}
+	}
+}
+
+// played credits the lineups on the floor with d of game clock time. Seconds is the time played
+// rounded to the nearest second.
+func (lt *lineupStatsTracker) played(active map[data.GameTeamSide]lineup, d time.Duration) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	for s, lnp := range active {
+		ls := lt.combo(s, lnp)
+		ls.played += d
+		ls.Seconds = wholeSeconds(ls.played)
+	}
+}
+
+// report returns a copy of the tracker's stats.
+func (lt *lineupStatsTracker) report() LineupReport {
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+
+	plusMinus := make(map[string]int, len(lt.plusMinus))
+	for pin, pm := range lt.plusMinus {
+		plusMinus[pin] = pm
+	}
+
+	list := func(side data.GameTeamSide) []LineupStats {
+		combos := make([]LineupStats, 0, len(lt.combos[side]))
+		for _, ls := range lt.combos[side] {
+			combos = append(combos, *ls)
+		}
+		slices.SortFunc(combos, func(a, b LineupStats) int {
+			if a.Seconds != b.Seconds {
+				return b.Seconds - a.Seconds
+			}
+			return strings.Compare(strings.Join(a.Players, ","), strings.Join(b.Players, ","))
+		})
+		return combos
+	}
+
+	return LineupReport{
+		PlusMinus: plusMinus,
+		Home:      list(data.TeamHome),
+		Away:      list(data.TeamAway),
+	}
+}
+
+// scored counts a change in the score against the players on the floor, or those on it when the
+// event being corrected executed, sends keepers and watchers the new lineup stats, and gives the
+// clock the new margin.
+func (h *Hub) scored(homeBefore, awayBefore int) {
+	home, away := h.Stats.GetScore()
+	if home == homeBefore && away == awayBefore {
+		return
+	}
+	h.Clock.SetMargin(home - away)
+
+	active := h.Lineups.getActive()
+	if h.correcting != nil {
+		active = h.correcting.active
+	}
+	if home != homeBefore {
+		h.LineupStats.scored(active, data.TeamHome, home-homeBefore)
+	}
+	if away != awayBefore {
+		h.LineupStats.scored(active, data.TeamAway, away-awayBefore)
+	}
+	h.sendLineupStats()
+}
+
+func (h *Hub) sendLineupStats() {
+	msg := h.message(msgLineupStats, h.LineupStats.report())
+	h.ToAllKeepers(msg)
+	h.ToAllWatchers(msg)
+}
@@ -8,11 +8,12 @@ import (
 
 type lineup []*data.Player
 type lineupManager struct {
-	home     lineup
-	away     lineup
-	homeDnp  lineup
-	awayDnp  lineup
-	teamSize int
+	home      lineup
+	away      lineup
+	homeDnp   lineup
+	awayDnp   lineup
+	fouledOut map[string]bool
+	teamSize  int
 }
 
 func (lm *lineupManager) getActive() map[data.GameTeamSide]lineup {
@@ -63,7 +64,25 @@ func (lm *lineupManager) isActive(playerPin string) bool {
 	})
 }
 
-func (lm *lineupManager) substitution(side data.GameTeamSide, outPin string, inPin string) {
+// find returns the player with playerPin from either team, including players that did not play.
+func (lm *lineupManager) find(playerPin string) (*data.Player, data.GameTeamSide, bool) {
+	sides := map[data.GameTeamSide][]lineup{
+		data.TeamHome: {lm.home, lm.homeDnp},
+		data.TeamAway: {lm.away, lm.awayDnp},
+	}
+	for side, lineups := range sides {
+		for _, lnp := range lineups {
+			for _, p := range lnp {
+				if p.PinId.Pin == playerPin {
+					return p, side, true
+				}
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+func (lm *lineupManager) substitution(side data.GameTeamSide, outPin string, inPin string) error {
 	var lnp *lineup
 	switch side {
 	case data.TeamHome:
@@ -81,7 +100,7 @@ func (lm *lineupManager) substitution(side data.GameTeamSide, outPin string, inP
 		}
 	}
 	if outPlayer == nil {
-		return
+		return ErrPlayerNotActive
 	}
 
 	for i := lm.teamSize; i < len(*lnp); i++ {
@@ -91,14 +110,17 @@ func (lm *lineupManager) substitution(side data.GameTeamSide, outPin string, inP
 		}
 	}
 	if inPlayer == nil {
-		return
+		return ErrPlayerNotOnBench
+	}
+	if lm.fouledOut[inPin] {
+		return ErrPlayerFouledOut
 	}
 	*inPlayer.LineupPos = outIdx + 1
 	*outPlayer.LineupPos = inIdx + 1
 	(*lnp)[outIdx] = inPlayer
 	(*lnp)[inIdx] = outPlayer
 
-	return
+	return nil
 }
 
 func newLineupManager(g *data.Game) *lineupManager {
@@ -122,10 +144,11 @@ func newLineupManager(g *data.Game) *lineupManager {
 	}
 
 	return &lineupManager{
-		home:     homeLnp,
-		away:     awayLnp,
-		homeDnp:  homeDnp,
-		awayDnp:  awayDnp,
-		teamSize: int(g.TeamSize),
+		home:      homeLnp,
+		away:      awayLnp,
+		homeDnp:   homeDnp,
+		awayDnp:   awayDnp,
+		fouledOut: make(map[string]bool),
+		teamSize:  int(g.TeamSize),
 	}
 }
@@ -0,0 +1,84 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"time"
+)
+
+// minutesTracker credits the players on the floor with the game clock time that runs while they
+// are on it. A stint is open from when the clock starts until it stops. A running clock allows
+// substitutions during a stint, so the stint is flushed before each one. Time played is added up
+// exactly and only rounded to whole seconds for the SecondsPlayed stat, so stints that end between
+// seconds lose nothing.
+type minutesTracker struct {
+	running bool
+	mark    time.Duration
+	played  map[string]time.Duration
+}
+
+func newMinutesTracker() *minutesTracker {
+	return &minutesTracker{played: make(map[string]time.Duration)}
+}
+
+// start opens a stint at remaining on the game clock.
+func (mt *minutesTracker) start(remaining time.Duration) {
+	mt.running = true
+	mt.mark = remaining
+}
+
+// checkpoint credits the players on the floor with the time run since the last checkpoint, if a
+// stint is open, and returns the time credited.
+func (mt *minutesTracker) checkpoint(h *Hub, remaining time.Duration) time.Duration {
+	if !mt.running {
+		return 0
+	}
+	elapsed := mt.mark - remaining
+	mt.mark = remaining
+	if elapsed <= 0 {
+		return 0
+	}
+
+	active := h.Lineups.getActive()
+	for _, lnp := range active {
+		for _, p := range lnp {
+			pin := p.PinId.Pin
+			before := wholeSeconds(mt.played[pin])
+			mt.played[pin] += elapsed
+			if seconds := wholeSeconds(mt.played[pin]) - before; seconds != 0 {
+				h.Stats.Add(pin, stats.SecondsPlayed, seconds)
+			}
+		}
+	}
+	h.LineupStats.played(active, elapsed)
+	return elapsed
+}
+
+// wholeSeconds rounds d to the nearest second.
+func wholeSeconds(d time.Duration) int {
+	return int(d.Round(time.Second) / time.Second)
+}
+
+// stop closes the open stint at remaining on the game clock and sends watchers the new minutes of
+// the players on the floor, and keepers and watchers the new lineup stats.
+func (mt *minutesTracker) stop(h *Hub, remaining time.Duration) {
+	mt.flush(h, remaining)
+	mt.running = false
+}
+
+// flush checkpoints the open stint at remaining on the game clock, sending the new minutes and
+// lineup stats like stop does, without closing it.
+func (mt *minutesTracker) flush(h *Hub, remaining time.Duration) {
+	if mt.checkpoint(h, remaining) == 0 {
+		return
+	}
+
+	changes := make([]statChange, 0)
+	for _, side := range []data.GameTeamSide{data.TeamHome, data.TeamAway} {
+		for _, p := range h.Lineups.getActive()[side] {
+			changes = append(changes, statChange{p.PinId.Pin, stats.SecondsPlayed})
+		}
+	}
+	h.statsDelta(changes...)
+	h.sendLineupStats()
+}
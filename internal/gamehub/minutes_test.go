@@ -0,0 +1,35 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"testing"
+	"time"
+)
+
+func TestMinutes(t *testing.T) {
+	h := newTestHub(t)
+	sub := &GameSubstitutionEvent{Side: data.TeamHome, In: "h3", Out: "h1"}
+
+	// Three stints of 1.4s are 4.2s played, not three seconds lost to rounding each one down.
+	for _, at := range []time.Duration{60 * time.Second, 50 * time.Second, 40 * time.Second} {
+		h.Minutes.start(at)
+		h.Minutes.stop(h, at-1400*time.Millisecond)
+	}
+	assert.Equal(t, h.Stats.GetPrimitive("h1", stats.SecondsPlayed), 4)
+	assert.Equal(t, h.Stats.GetPrimitive("h3", stats.SecondsPlayed), 0)
+	assert.Equal(t, h.LineupStats.report().Home[0].Seconds, 4)
+
+	// A stint flushed at a substitution keeps its fraction of a second for the player subbed out.
+	h.Minutes.start(30 * time.Second)
+	err := h.Clock.Set("00:29.5")
+	assert.NilError(t, err)
+	_, err = execute(h, sub)
+	assert.NilError(t, err)
+	h.Minutes.stop(h, 28*time.Second)
+	assert.Equal(t, h.Stats.GetPrimitive("h1", stats.SecondsPlayed), 5)
+	assert.Equal(t, h.Stats.GetPrimitive("h2", stats.SecondsPlayed), 6)
+	assert.Equal(t, h.Stats.GetPrimitive("h3", stats.SecondsPlayed), 2)
+	assert.Equal(t, h.Stats.GetPlayerStat("h2", "Min"), any("0:06"))
+}
@@ -1,32 +1,167 @@
 package gamehub
 
-import "ScoreTableApi/internal/data"
-
-//type PlayEngine struct {
-//	in  chan GameEvent
-//	out chan Play
-//}
-//
-//func (e *PlayEngine) Run() {
-//	for {
-//		select {
-//		case <-in
-//		}
-//	}
-//}
+import (
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"fmt"
+	"sync"
+)
+
+// PlayEngine turns executed GameEvent's into the game's play-by-play feed. Plays of voided events
+// are kept but hidden, so a redo can show them again.
+type PlayEngine struct {
+	plays []*PlayerPlay
+	mu    sync.RWMutex
+}
 
 type PlayerPlay struct {
-	period *int64
-	time   *string
-	team   struct {
-		name string
-		pin  string
-		side data.GameTeamSide
-	}
-	player struct {
-		name   string
-		pin    string
-		number int
-	}
-	description string
+	EventID int64   `json:"event_id"`
+	Period  *int64  `json:"period"`
+	Time    *string `json:"time"`
+	Team    struct {
+		Name string            `json:"name"`
+		Pin  string            `json:"pin"`
+		Side data.GameTeamSide `json:"side"`
+	} `json:"team"`
+	Player struct {
+		Name   string `json:"name"`
+		Pin    string `json:"pin"`
+		Number int    `json:"number"`
+	} `json:"player"`
+	Description string `json:"description"`
+	voided      bool
+}
+
+// playMaker is implemented by GameEvent's that appear in the play-by-play.
+type playMaker interface {
+	describe(h *Hub) (playerPin string, description string)
+}
+
+var statDescriptions = map[stats.PrimitiveStat]string{
+	stats.Point:            "scored",
+	stats.ThreePointMade:   "made 3PT",
+	stats.ThreePointMiss:   "missed 3PT",
+	stats.TwoPointMade:     "made 2PT",
+	stats.TwoPointMiss:     "missed 2PT",
+	stats.FreeThrowMade:    "made FT",
+	stats.FreeThrowMiss:    "missed FT",
+	stats.Assist:           "assist",
+	stats.Block:            "block",
+	stats.Steal:            "steal",
+	stats.OffensiveRebound: "offensive rebound",
+	stats.DefensiveRebound: "defensive rebound",
+	stats.Rebound:          "rebound",
+	stats.Turnover:         "turnover",
+	stats.Foul:             "foul",
+}
+
+var scoringStats = map[stats.PrimitiveStat]bool{
+	stats.Point:          true,
+	stats.ThreePointMade: true,
+	stats.TwoPointMade:   true,
+	stats.FreeThrowMade:  true,
+}
+
+// record creates a PlayerPlay for an executed event if it appears in the play-by-play.
+func (e *PlayEngine) record(h *Hub, id int64, event GameEvent) *PlayerPlay {
+	maker, ok := event.(playMaker)
+	if !ok {
+		return nil
+	}
+	playerPin, description := maker.describe(h)
+	player, side, ok := h.Lineups.find(playerPin)
+	if !ok {
+		return nil
+	}
+
+	period := h.Clock.GetPeriod()
+	clockTime := h.Clock.GetGameClock()
+	play := &PlayerPlay{
+		EventID:     id,
+		Period:      &period,
+		Time:        &clockTime,
+		Description: description,
+	}
+	play.Player.Name = shortName(player)
+	play.Player.Pin = player.PinId.Pin
+	play.Player.Number = player.Number
+
+	team := h.Game.Teams.Home
+	if side == data.TeamAway {
+		team = h.Game.Teams.Away
+	}
+	if team != nil {
+		play.Team.Name = team.Name
+		play.Team.Pin = team.PinID.Pin
+	}
+	play.Team.Side = side
+
+	e.mu.Lock()
+	e.plays = append(e.plays, play)
+	e.mu.Unlock()
+	return play
+}
+
+// setVoided hides or shows again the plays of the event with id, returning them.
+func (e *PlayEngine) setVoided(id int64, voided bool) []*PlayerPlay {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	changed := make([]*PlayerPlay, 0)
+	for _, p := range e.plays {
+		if p.EventID == id {
+			p.voided = voided
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
+
+// List returns the plays of the game that have not been voided, in order.
+func (e *PlayEngine) List() []PlayerPlay {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	plays := make([]PlayerPlay, 0, len(e.plays))
+	for _, p := range e.plays {
+		if !p.voided {
+			plays = append(plays, *p)
+		}
+	}
+	return plays
+}
+
+func (e GameStatEvent) describe(h *Hub) (string, string) {
+	description, ok := statDescriptions[e.Stat]
+	if !ok {
+		description = string(e.Stat)
+	}
+	if e.Action == subtract {
+		description += " removed"
+	}
+
+	player, _, _ := h.Lineups.find(e.PlayerPin)
+	description = fmt.Sprintf("%s %s", shortName(player), description)
+	if scoringStats[e.Stat] {
+		description += fmt.Sprintf(" (%v PTS)", h.Stats.GetPlayerStat(e.PlayerPin, "Pts"))
+	}
+
+	return e.PlayerPin, description
+}
+
+func (e GameSubstitutionEvent) describe(h *Hub) (string, string) {
+	in, _, _ := h.Lineups.find(e.In)
+	out, _, _ := h.Lineups.find(e.Out)
+	return e.In, fmt.Sprintf("%s checks in for %s", shortName(in), shortName(out))
+}
+
+// shortName formats a player's name as first initial and last name, e.g. "J. Smith".
+func shortName(p *data.Player) string {
+	if p == nil {
+		return ""
+	}
+	if p.FirstName == "" {
+		return p.LastName
+	}
+	return fmt.Sprintf("%s. %s", string([]rune(p.FirstName)[0]), p.LastName)
 }
@@ -0,0 +1,240 @@
+// Package gamehub runs live games. Keepers and watchers connected to a game's Hub exchange JSON
+// messages wrapped in a versioned envelope:
+//
+//	{"v": 1, "type": "stat", "id": "c-42", "payload": {...}}
+//
+// Keepers send the following message types, with id set to a client-chosen correlation ID:
+//
+//	stat          {"player_pin": string, "stat": stats.PrimitiveStat other than "Sec",
+//	              "action": 0 (add) | 1 (subtract),
+//	              "x": float, "y": float (optional, shots only, 0 to 1 on a half court)}
+//	clock         {"action": clock.Control, "value": string (optional)}, where value is "MM:SS",
+//	              "MM:SS.t" or "SS.t" for SetClock and the period number for SetPeriod
+//	compound      {"stats": [stat payload]}, 2 to 5 stats applied and undone together as one play
+//	substitution  {"side": "home" | "away", "in": player pin, "out": player pin}
+//	undo          {}
+//	redo          {}
+//	void          {"event_id": int}
+//	end           {}
+//
+// Every keeper message is answered on that keeper's connection only, with the message's id, by
+// either an ack carrying the server-assigned event ID, or an error:
+//
+//	ack    {"event_id": int}
+//	error  {"code": string, "message": string}
+//
+// Error codes are malformed, unsupported_version, unknown_type, invalid_payload, forbidden and
+// rejected. A keeper that sends a message that cannot be handled stays connected.
+//
+// Watchers connected by websocket may send a resync message, with no payload, to be sent the full
+// statline again. Other messages from watchers are ignored.
+//
+// The hub sends keepers and watchers the following message types, without an id:
+//
+//	snapshot     the full state of the game, sent once on connect
+//	stats        {"seq": int, "stats": the game's statline}, sent in reply to resync
+//	stats_delta  {"seq": int, "stats": the stats that changed}
+//	clock        {"event": clock.EventType, "value": string}
+//	lineups      {"active", "bench", "dnp", "subs"} (keepers only)
+//	play         a PlayerPlay
+//	fouls        {"period": int, "home": {"fouls": int, "bonus": bonus}, "away": {...},
+//	             "players": {pin: int}, "fouled_out": [pin]}, sent when a foul is added or removed.
+//	             A side's bonus is none, bonus or double_bonus, from its opponents' fouls.
+//	lineup_stats {"plus_minus": {pin: int}, "home": [LineupStats], "away": [LineupStats]}, sent
+//	             when the score changes and when the clock stops
+//	voided       {"event_id": int}
+//	restored     {"event_id": int}
+//	game_point   {"sides": [side], "score": {"home": int, "away": int}}, sent when the teams a
+//	             point away from winning a target game change
+//	winner       {"side": side, "score": {"home": int, "away": int}}, sent when a team reaches
+//	             the target of a target game, which a keeper ends with an end message. side is
+//	             null if the winning basket is taken back.
+//	final        {"score": {"home": int, "away": int}, "stats": the game's statline}, sent when
+//	             the game ends, before every connection is closed
+//
+// A side is always "home" or "away", including as the key of a map of sides, such as the lineups.
+//
+// Watchers can also subscribe to a Hub as a Server-Sent Events stream, where each message is the
+// data of one event.
+package gamehub
+
+import (
+	"bytes"
+	json2 "encoding/json"
+	"errors"
+)
+
+// protocolVersion is the version of the message envelope the hub speaks.
+const protocolVersion = 1
+
+var (
+	ErrMalformedMessage   = errors.New("message is not a valid envelope")
+	ErrUnsupportedVersion = errors.New("unsupported protocol version")
+	ErrUnknownMessageType = errors.New("unknown message type")
+)
+
+type messageType string
+
+const (
+	msgStat         messageType = "stat"
+	msgCompound     messageType = "compound"
+	msgClock        messageType = "clock"
+	msgSubstitution messageType = "substitution"
+	msgUndo         messageType = "undo"
+	msgRedo         messageType = "redo"
+	msgVoid         messageType = "void"
+	msgEnd          messageType = "end"
+	msgResync       messageType = "resync"
+
+	msgSnapshot    messageType = "snapshot"
+	msgStats       messageType = "stats"
+	msgStatsDelta  messageType = "stats_delta"
+	msgLineups     messageType = "lineups"
+	msgPlay        messageType = "play"
+	msgVoided      messageType = "voided"
+	msgRestored    messageType = "restored"
+	msgFinal       messageType = "final"
+	msgGamePoint   messageType = "game_point"
+	msgWinner      messageType = "winner"
+	msgFouls       messageType = "fouls"
+	msgLineupStats messageType = "lineup_stats"
+	msgAck         messageType = "ack"
+	msgError       messageType = "error"
+)
+
+// messageEventTypes maps the message types keepers may send to the GameEvent they carry.
+var messageEventTypes = map[messageType]GameEventType{
+	msgStat:         stat,
+	msgCompound:     compound,
+	msgClock:        gameClock,
+	msgSubstitution: substitution,
+	msgUndo:         undo,
+	msgRedo:         redo,
+	msgVoid:         void,
+	msgEnd:          end,
+}
+
+// inboundMessage is the envelope of a message sent by a keeper.
+type inboundMessage struct {
+	Version int              `json:"v"`
+	Type    messageType      `json:"type"`
+	ID      string           `json:"id"`
+	Payload json2.RawMessage `json:"payload"`
+}
+
+// outboundMessage is the envelope of a message sent by the hub.
+type outboundMessage struct {
+	Version int         `json:"v"`
+	Type    messageType `json:"type"`
+	ID      string      `json:"id,omitempty"`
+	Payload any         `json:"payload"`
+}
+
+type protocolError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// parseMessage decodes a keeper message into a keeperEvent. If the message cannot be handled, the
+// returned keeperEvent carries the error, along with the correlation ID if it could be read.
+func parseMessage(msg []byte) keeperEvent {
+	var in inboundMessage
+	err := json2.Unmarshal(msg, &in)
+	if err != nil {
+		return keeperEvent{err: ErrMalformedMessage}
+	}
+	ke := keeperEvent{id: in.ID}
+
+	if in.Version != protocolVersion {
+		ke.err = ErrUnsupportedVersion
+		return ke
+	}
+	t, ok := messageEventTypes[in.Type]
+	if !ok {
+		ke.err = ErrUnknownMessageType
+		return ke
+	}
+
+	event, err := newEventOfType(t)
+	if err != nil {
+		ke.err = err
+		return ke
+	}
+	if len(in.Payload) > 0 {
+		dec := json2.NewDecoder(bytes.NewReader(in.Payload))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(event)
+		if err != nil {
+			ke.err = ErrEventParseFailed
+			return ke
+		}
+	}
+	err = event.validate()
+	if err != nil {
+		ke.err = err
+		return ke
+	}
+
+	ke.GameEvent = event
+	return ke
+}
+
+// isResync reports whether a watcher message is a request for the full statline.
+func isResync(msg []byte) bool {
+	var in inboundMessage
+	err := json2.Unmarshal(msg, &in)
+	if err != nil {
+		return false
+	}
+	return in.Version == protocolVersion && in.Type == msgResync
+}
+
+// errorCode returns the protocol error code sent to a keeper for err.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrMalformedMessage):
+		return "malformed"
+	case errors.Is(err, ErrUnsupportedVersion):
+		return "unsupported_version"
+	case errors.Is(err, ErrUnknownMessageType):
+		return "unknown_type"
+	case errors.Is(err, ErrEventParseFailed), errors.Is(err, ErrEventValidationFailed):
+		return "invalid_payload"
+	case errors.Is(err, ErrKeeperRoleForbidden):
+		return "forbidden"
+	default:
+		return "rejected"
+	}
+}
+
+// message encodes an outbound message of type t with payload.
+func (h *Hub) message(t messageType, payload any) []byte {
+	return h.reply(t, "", payload)
+}
+
+// reply encodes an outbound message of type t with payload, answering the keeper message with id.
+func (h *Hub) reply(t messageType, id string, payload any) []byte {
+	msg, err := json2.Marshal(outboundMessage{
+		Version: protocolVersion,
+		Type:    t,
+		ID:      id,
+		Payload: payload,
+	})
+	if err != nil {
+		h.logger.PrintError(err, map[string]string{"game": h.Game.PinID.Pin})
+	}
+	return msg
+}
+
+// ack answers the keeper message with id, that was executed as the event with eventID.
+func (h *Hub) ack(k *Keeper, id string, eventID int64) {
+	h.ToKeeper(k, h.reply(msgAck, id, envelope{"event_id": eventID}))
+}
+
+// reject answers the keeper message with id with err.
+func (h *Hub) reject(k *Keeper, id string, err error) {
+	h.ToKeeper(k, h.reply(msgError, id, protocolError{
+		Code:    errorCode(err),
+		Message: err.Error(),
+	}))
+}
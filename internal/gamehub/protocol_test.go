@@ -0,0 +1,82 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/data"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       string
+		id        string
+		eventType GameEventType
+		err       error
+	}{
+		{name: "Stat", msg: `{"v": 1, "type": "stat", "id": "c-1", "payload": {"player_pin": "h1", ` +
+			`"stat": "2PtM", "action": 0, "x": 0.5, "y": 0.1}}`, id: "c-1", eventType: stat},
+		{name: "Substitution", msg: `{"v": 1, "type": "substitution", "id": "c-2", "payload": ` +
+			`{"side": "away", "in": "a3", "out": "a1"}}`, id: "c-2", eventType: substitution},
+		{name: "No Payload", msg: `{"v": 1, "type": "undo", "id": "c-3"}`, id: "c-3",
+			eventType: undo},
+		{name: "Malformed", msg: `{"v": 1, "type": `, err: ErrMalformedMessage},
+		{name: "Unsupported Version", msg: `{"v": 2, "type": "undo", "id": "c-4"}`, id: "c-4",
+			err: ErrUnsupportedVersion},
+		{name: "Unknown Type", msg: `{"v": 1, "type": "resync", "id": "c-5"}`, id: "c-5",
+			err: ErrUnknownMessageType},
+		{name: "Unknown Field", msg: `{"v": 1, "type": "void", "id": "c-6", "payload": ` +
+			`{"event_id": 1, "seq": 1}}`, id: "c-6", err: ErrEventParseFailed},
+		{name: "Unknown Side", msg: `{"v": 1, "type": "substitution", "id": "c-7", "payload": ` +
+			`{"side": "visitors", "in": "a3", "out": "a1"}}`, id: "c-7", err: ErrEventParseFailed},
+		{name: "Unknown Stat", msg: `{"v": 1, "type": "stat", "id": "c-8", "payload": ` +
+			`{"player_pin": "h1", "stat": "Dunk", "action": 0}}`, id: "c-8",
+			err: ErrEventValidationFailed},
+		{name: "Seconds Played", msg: `{"v": 1, "type": "stat", "id": "c-9", "payload": ` +
+			`{"player_pin": "h1", "stat": "Sec", "action": 0}}`, id: "c-9",
+			err: ErrEventValidationFailed},
+		{name: "Location Without Shot", msg: `{"v": 1, "type": "stat", "id": "c-10", "payload": ` +
+			`{"player_pin": "h1", "stat": "Ast", "action": 0, "x": 0.5, "y": 0.5}}`, id: "c-10",
+			err: ErrEventValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ke := parseMessage([]byte(tt.msg))
+			assert.Equal(t, ke.id, tt.id)
+			assert.Equal(t, ke.err, tt.err)
+			if tt.err == nil {
+				assert.Equal(t, ke.GameEvent.eventType(), tt.eventType)
+			}
+		})
+	}
+
+	t.Run("Side", func(t *testing.T) {
+		ke := parseMessage([]byte(`{"v": 1, "type": "substitution", "payload": ` +
+			`{"side": "away", "in": "a3", "out": "a1"}}`))
+		assert.NilError(t, ke.err)
+		assert.Equal(t, ke.GameEvent.(*GameSubstitutionEvent).Side, data.TeamAway)
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{err: ErrMalformedMessage, code: "malformed"},
+		{err: ErrUnsupportedVersion, code: "unsupported_version"},
+		{err: ErrUnknownMessageType, code: "unknown_type"},
+		{err: ErrEventParseFailed, code: "invalid_payload"},
+		{err: ErrEventValidationFailed, code: "invalid_payload"},
+		{err: ErrKeeperRoleForbidden, code: "forbidden"},
+		{err: ErrNothingToUndo, code: "rejected"},
+		{err: ErrClockRunning, code: "rejected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, errorCode(tt.err), tt.code)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/clock"
+	"ScoreTableApi/internal/data"
+	json2 "encoding/json"
+	"strconv"
+)
+
+// record assigns the next sequence number to an executed GameEvent, queues it for the game event
+// log and returns the sequence number, which also serves as the event's ID. Writes happen on the
+// recorder goroutine so the hub loop never waits on the database. The event is logged at h.at, the
+// clock position it was executed from.
+func (h *Hub) record(userID int64, e GameEvent) int64 {
+	payload, err := json2.Marshal(e)
+	if err != nil {
+		h.logger.PrintError(err, map[string]string{"game": h.Game.PinID.Pin})
+		payload = []byte("null")
+	}
+
+	h.seq++
+	h.recorder <- &data.GameEvent{
+		GameID:     h.Game.ID,
+		Seq:        h.seq,
+		UserID:     userID,
+		Period:     h.at.Period,
+		Clock:      string(clock.FormatExact(h.at.Remaining)),
+		ClockState: int64(h.at.State),
+		Type:       int64(e.eventType()),
+		Payload:    payload,
+	}
+	return h.seq
+}
+
+// runRecorder writes queued events to the game event log, in order, until recorder is closed.
+func (h *Hub) runRecorder() {
+	defer close(h.recorded)
+	for event := range h.recorder {
+		err := h.models.GameEvents.Insert(event)
+		if err != nil {
+			h.logger.PrintError(err, map[string]string{
+				"game": h.Game.PinID.Pin,
+				"seq":  strconv.FormatInt(event.Seq, 10),
+			})
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/clock"
+	"ScoreTableApi/internal/data"
+	json2 "encoding/json"
+)
+
+// newEventOfType returns an empty GameEvent of the given type for a persisted payload to be
+// decoded into.
+func newEventOfType(t GameEventType) (GameEvent, error) {
+	switch t {
+	case stat:
+		return &GameStatEvent{}, nil
+	case gameClock:
+		return &GameClockEvent{}, nil
+	case substitution:
+		return &GameSubstitutionEvent{}, nil
+	case undo:
+		return &GameUndoEvent{}, nil
+	case redo:
+		return &GameRedoEvent{}, nil
+	case void:
+		return &GameVoidEvent{}, nil
+	case end:
+		return &GameEndEvent{}, nil
+	case compound:
+		return &GameCompoundEvent{}, nil
+	default:
+		return nil, ErrEventParseFailed
+	}
+}
+
+// parseLoggedEvent decodes a row of the game event log back into the GameEvent that produced it.
+func parseLoggedEvent(e *data.GameEvent) (GameEvent, error) {
+	event, err := newEventOfType(GameEventType(e.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	err = json2.Unmarshal(e.Payload, event)
+	if err != nil {
+		return nil, ErrEventParseFailed
+	}
+
+	return event, nil
+}
+
+// replay rebuilds the hub's statline, lineups and clock from a game's event log. It must be
+// called before Run, while the hub has no keepers or watchers.
+func (h *Hub) replay(events []*data.GameEvent) error {
+	h.replaying = true
+	defer func() {
+		h.replaying = false
+	}()
+
+	for _, e := range events {
+		event, err := parseLoggedEvent(e)
+		if err != nil {
+			return err
+		}
+		h.seq = e.Seq
+
+		remaining, err := clock.Duration(e.Clock).ToDuration()
+		if err != nil {
+			return err
+		}
+		pos := clock.Position{Period: e.Period, Remaining: remaining, State: clock.State(e.ClockState)}
+		err = h.Clock.Restore(pos)
+		if err != nil {
+			return err
+		}
+		// Clock ticks are not logged, so time played is credited at each logged event instead. A
+		// stint still open from an earlier period ran until that period ran out.
+		if pos.Period != h.at.Period {
+			h.Minutes.stop(h, 0)
+		}
+		h.at = pos
+		if h.Clock.Remaining() == 0 {
+			h.Minutes.stop(h, 0)
+		} else {
+			h.Minutes.checkpoint(h, h.Clock.Remaining())
+		}
+
+		// Only events that executed successfully were logged, so failures here are not fatal.
+		err = event.execute(h)
+		if err != nil {
+			continue
+		}
+		h.remember(e.Seq, event)
+	}
+	if !h.ended {
+		h.checkTarget()
+	}
+
+	return nil
+}
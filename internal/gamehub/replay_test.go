@@ -0,0 +1,106 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/clock"
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	json2 "encoding/json"
+	"testing"
+)
+
+// logged executes e on h and returns the row record would log for it.
+func logged(t *testing.T, h *Hub, e GameEvent) *data.GameEvent {
+	t.Helper()
+	id, err := execute(h, e)
+	assert.NilError(t, err)
+	payload, err := json2.Marshal(e)
+	assert.NilError(t, err)
+	return &data.GameEvent{
+		Seq:        id,
+		Period:     h.at.Period,
+		Clock:      string(clock.FormatExact(h.at.Remaining)),
+		ClockState: int64(h.at.State),
+		Type:       int64(e.eventType()),
+		Payload:    payload,
+	}
+}
+
+func clockEvent(action clock.Control, value string) *GameClockEvent {
+	e := &GameClockEvent{Action: action}
+	if value != "" {
+		e.Value = &value
+	}
+	return e
+}
+
+func TestReplayClock(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []GameEvent
+		period int64
+		clock  string
+	}{
+		{name: "Adjust Then Add Period", events: []GameEvent{
+			clockEvent(clock.SubtractSec, ""),
+			clockEvent(clock.AddPeriod, ""),
+		}, period: 2, clock: "01:00"},
+		{name: "Set Then Add Period", events: []GameEvent{
+			clockEvent(clock.SetClock, "00:30"),
+			clockEvent(clock.AddPeriod, ""),
+			clockEvent(clock.AddPeriod, ""),
+		}, period: 3, clock: "01:00"},
+		{name: "Add Period Then Adjust", events: []GameEvent{
+			clockEvent(clock.AddPeriod, ""),
+			clockEvent(clock.SubtractSec, ""),
+			statEvent("h1", "Pts"),
+		}, period: 2, clock: "59.0"},
+		{name: "Period Run Out", events: []GameEvent{
+			clockEvent(clock.SetClock, "00:00"),
+			clockEvent(clock.AddPeriod, ""),
+		}, period: 2, clock: "01:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := newTestHub(t)
+			var events []*data.GameEvent
+			for _, e := range tt.events {
+				events = append(events, logged(t, live, e))
+			}
+			assert.Equal(t, live.Clock.GetPeriod(), tt.period)
+			assert.Equal(t, live.Clock.Get(), tt.clock)
+
+			h := newTestHub(t)
+			err := h.replay(events)
+			assert.NilError(t, err)
+			assert.Equal(t, h.Clock.GetPeriod(), tt.period)
+			assert.Equal(t, h.Clock.Get(), tt.clock)
+			assert.Equal(t, h.Clock.GetState(), live.Clock.GetState())
+		})
+	}
+}
+
+func TestReplayMinutes(t *testing.T) {
+	row := func(seq, period int64, at string, state clock.State, e GameEvent) *data.GameEvent {
+		payload, err := json2.Marshal(e)
+		assert.NilError(t, err)
+		return &data.GameEvent{Seq: seq, Period: period, Clock: at, ClockState: int64(state),
+			Type: int64(e.eventType()), Payload: payload}
+	}
+
+	// The first period runs out with no event logged at its end, so the stint open from 00:30.4 is
+	// credited when the next event is logged in period 2.
+	h := newTestHub(t)
+	err := h.replay([]*data.GameEvent{
+		row(1, 1, "01:00", clock.StateFresh, clockEvent(clock.Play, "")),
+		row(2, 1, "00:30.4", clock.StatePlaying,
+			&GameSubstitutionEvent{Side: data.TeamHome, In: "h3", Out: "h1"}),
+		row(3, 2, "01:00", clock.StateFresh, clockEvent(clock.Play, "")),
+		row(4, 2, "00:40", clock.StatePlaying, clockEvent(clock.Pause, "")),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, h.Stats.GetPrimitive("h1", stats.SecondsPlayed), 30)
+	assert.Equal(t, h.Stats.GetPrimitive("h2", stats.SecondsPlayed), 80)
+	assert.Equal(t, h.Stats.GetPrimitive("h3", stats.SecondsPlayed), 50)
+}
@@ -0,0 +1,200 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Shot locations are normalized to a half court, with x running from the left sideline (0) to the
+// right sideline (1), and y from the baseline (0) to half court (1). The court measurements below
+// are in feet and are only used to place shots in zones.
+const (
+	courtWidth      = 50.0
+	halfCourtLength = 47.0
+	basketDistance  = 5.25
+	restrictedArea  = 4.0
+	laneWidth       = 16.0
+	laneLength      = 19.0
+	cornerLength    = 14.0
+)
+
+type shotZone string
+
+const (
+	zoneRestrictedArea shotZone = "restricted_area"
+	zonePaint          shotZone = "paint"
+	zoneMidRange       shotZone = "mid_range"
+	zoneCornerThree    shotZone = "corner_three"
+	zoneAboveBreak     shotZone = "above_break_three"
+)
+
+// shotStats are the PrimitiveStat's that may carry a shot location, and whether they are makes.
+var shotStats = map[stats.PrimitiveStat]bool{
+	stats.TwoPointMade:   true,
+	stats.TwoPointMiss:   false,
+	stats.ThreePointMade: true,
+	stats.ThreePointMiss: false,
+}
+
+// ShotChart keeps the located shots of a game. Shots of voided events are kept but hidden, so a
+// redo can show them again.
+type ShotChart struct {
+	shots []*Shot
+	mu    sync.RWMutex
+}
+
+type Shot struct {
+	EventID   int64             `json:"event_id"`
+	PlayerPin string            `json:"player_pin"`
+	TeamPin   string            `json:"team_pin"`
+	Side      data.GameTeamSide `json:"side"`
+	Period    int64             `json:"period"`
+	Time      string            `json:"time"`
+	X         float64           `json:"x"`
+	Y         float64           `json:"y"`
+	Points    int               `json:"points"`
+	Made      bool              `json:"made"`
+	Zone      shotZone          `json:"zone"`
+	voided    bool
+}
+
+// ShotFilter narrows a ShotChart to the shots of one player or one team. Empty fields match
+// every shot.
+type ShotFilter struct {
+	PlayerPin string
+	TeamPin   string
+}
+
+// ZoneStats are the makes and attempts of a set of shots in one zone.
+type ZoneStats struct {
+	Made      int    `json:"made"`
+	Attempted int    `json:"attempted"`
+	Percent   string `json:"percent"`
+}
+
+// record adds the located shots added by an executed event.
+func (sc *ShotChart) record(h *Hub, id int64, event GameEvent) {
+	switch e := event.(type) {
+	case *GameStatEvent:
+		sc.recordStat(h, id, *e)
+	case *GameCompoundEvent:
+		for _, s := range e.Stats {
+			sc.recordStat(h, id, s)
+		}
+	}
+}
+
+// recordStat adds the shot of a stat of the event with id, if it is a located shot being added.
+func (sc *ShotChart) recordStat(h *Hub, id int64, e GameStatEvent) {
+	if e.Action != add || e.X == nil || e.Y == nil {
+		return
+	}
+	made, ok := shotStats[e.Stat]
+	if !ok {
+		return
+	}
+	_, side, ok := h.Lineups.find(e.PlayerPin)
+	if !ok {
+		return
+	}
+
+	points := 2
+	if e.Stat == stats.ThreePointMade || e.Stat == stats.ThreePointMiss {
+		points = 3
+	}
+	shot := &Shot{
+		EventID:   id,
+		PlayerPin: e.PlayerPin,
+		Side:      side,
+		Period:    h.Clock.GetPeriod(),
+		Time:      h.Clock.GetGameClock(),
+		X:         *e.X,
+		Y:         *e.Y,
+		Points:    points,
+		Made:      made,
+		Zone:      zoneOf(*e.X, *e.Y, points),
+	}
+	team := h.Game.Teams.Home
+	if side == data.TeamAway {
+		team = h.Game.Teams.Away
+	}
+	if team != nil {
+		shot.TeamPin = team.PinID.Pin
+	}
+
+	sc.mu.Lock()
+	sc.shots = append(sc.shots, shot)
+	sc.mu.Unlock()
+}
+
+// setVoided hides or shows again the shot of the event with id.
+func (sc *ShotChart) setVoided(id int64, voided bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for _, s := range sc.shots {
+		if s.EventID == id {
+			s.voided = voided
+		}
+	}
+}
+
+// List returns the shots that match filter and have not been voided, in order, along with the
+// makes and attempts of those shots in each zone.
+func (sc *ShotChart) List(filter ShotFilter) ([]Shot, map[shotZone]ZoneStats) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+
+	shots := make([]Shot, 0)
+	zones := make(map[shotZone]ZoneStats)
+	for _, s := range sc.shots {
+		if s.voided {
+			continue
+		}
+		if filter.PlayerPin != "" && s.PlayerPin != filter.PlayerPin {
+			continue
+		}
+		if filter.TeamPin != "" && s.TeamPin != filter.TeamPin {
+			continue
+		}
+		shots = append(shots, *s)
+
+		zs := zones[s.Zone]
+		zs.Attempted++
+		if s.Made {
+			zs.Made++
+		}
+		zones[s.Zone] = zs
+	}
+	for z, zs := range zones {
+		zs.Percent = fmt.Sprintf("%.1f%%", float64(zs.Made)/float64(zs.Attempted)*100)
+		zones[z] = zs
+	}
+
+	return shots, zones
+}
+
+// zoneOf returns the zone of a shot worth points at x, y. Whether a shot is a two or a three is
+// taken from the stat the keeper sent rather than the location.
+func zoneOf(x, y float64, points int) shotZone {
+	dx := (x - 0.5) * courtWidth
+	dy := y * halfCourtLength
+
+	if points == 3 {
+		if dy <= cornerLength {
+			return zoneCornerThree
+		}
+		return zoneAboveBreak
+	}
+	switch {
+	case math.Hypot(dx, dy-basketDistance) <= restrictedArea:
+		return zoneRestrictedArea
+	case math.Abs(dx) <= laneWidth/2 && dy <= laneLength:
+		return zonePaint
+	default:
+		return zoneMidRange
+	}
+}
@@ -0,0 +1,95 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/stats"
+	"testing"
+)
+
+func TestZoneOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		x      float64
+		y      float64
+		points int
+		zone   shotZone
+	}{
+		{name: "Restricted Area", x: 0.5, y: 0.11, points: 2, zone: zoneRestrictedArea},
+		{name: "Paint", x: 0.5, y: 0.3, points: 2, zone: zonePaint},
+		{name: "Beside Lane", x: 0.1, y: 0.3, points: 2, zone: zoneMidRange},
+		{name: "Above Lane", x: 0.5, y: 0.5, points: 2, zone: zoneMidRange},
+		{name: "Corner Three", x: 0.02, y: 0.1, points: 3, zone: zoneCornerThree},
+		{name: "Above The Break", x: 0.5, y: 0.6, points: 3, zone: zoneAboveBreak},
+		// The keeper's stat decides the points, even where the location disagrees.
+		{name: "Three In The Paint", x: 0.5, y: 0.3, points: 3, zone: zoneAboveBreak},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, zoneOf(tt.x, tt.y, tt.points), tt.zone)
+		})
+	}
+}
+
+func TestShotChartList(t *testing.T) {
+	h := newTestHub(t)
+	shot := func(playerPin string, stat stats.PrimitiveStat, x, y float64) int64 {
+		e := statEvent(playerPin, stat)
+		e.X, e.Y = &x, &y
+		id, err := execute(h, e)
+		assert.NilError(t, err)
+		return id
+	}
+	shot("h1", stats.TwoPointMade, 0.5, 0.3)
+	shot("h1", stats.TwoPointMiss, 0.5, 0.3)
+	shot("h2", stats.ThreePointMade, 0.02, 0.1)
+	voided := shot("h2", stats.TwoPointMade, 0.5, 0.3)
+	shot("a1", stats.ThreePointMiss, 0.5, 0.6)
+	// Shots without a location are not charted.
+	_, err := execute(h, statEvent("h1", stats.TwoPointMade))
+	assert.NilError(t, err)
+	_, err = execute(h, &GameVoidEvent{EventID: voided})
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name   string
+		filter ShotFilter
+		shots  int
+		zones  map[shotZone]ZoneStats
+	}{
+		{name: "All", shots: 4, zones: map[shotZone]ZoneStats{
+			zonePaint:       {Made: 1, Attempted: 2, Percent: "50.0%"},
+			zoneCornerThree: {Made: 1, Attempted: 1, Percent: "100.0%"},
+			zoneAboveBreak:  {Made: 0, Attempted: 1, Percent: "0.0%"},
+		}},
+		{name: "Player", filter: ShotFilter{PlayerPin: "h2"}, shots: 1,
+			zones: map[shotZone]ZoneStats{
+				zoneCornerThree: {Made: 1, Attempted: 1, Percent: "100.0%"},
+			}},
+		{name: "Team", filter: ShotFilter{TeamPin: "away"}, shots: 1,
+			zones: map[shotZone]ZoneStats{
+				zoneAboveBreak: {Made: 0, Attempted: 1, Percent: "0.0%"},
+			}},
+		{name: "No Shots", filter: ShotFilter{PlayerPin: "a2"}, zones: map[shotZone]ZoneStats{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shots, zones := h.Shots.List(tt.filter)
+			assert.Equal(t, len(shots), tt.shots)
+			assert.Equal(t, len(zones), len(tt.zones))
+			for z, zs := range tt.zones {
+				assert.Equal(t, zones[z], zs)
+			}
+		})
+	}
+
+	t.Run("Redone", func(t *testing.T) {
+		err := h.restore(voided)
+		assert.NilError(t, err)
+		shots, zones := h.Shots.List(ShotFilter{PlayerPin: "h2"})
+		assert.Equal(t, len(shots), 2)
+		assert.Equal(t, shots[1].EventID, voided)
+		assert.Equal(t, zones[zonePaint], ZoneStats{Made: 1, Attempted: 1, Percent: "100.0%"})
+	})
+}
@@ -0,0 +1,76 @@
+package gamehub
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrStreamingUnsupported = errors.New("response does not support streaming")
+
+// StreamWatcher subscribes to the hub's watcher broadcasts and writes them to wr as a Server-Sent
+// Events stream, until the client disconnects or the hub drops it. Each event's ID can be sent
+// back as the Last-Event-ID header to resume the stream after a reconnect.
+func (h *Hub) StreamWatcher(wr http.ResponseWriter, r *http.Request) error {
+	flusher, ok := wr.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+	// The stream outlives the server's write timeout. If the deadline cannot be lifted, the client
+	// reconnects with Last-Event-ID once it is reached.
+	_ = http.NewResponseController(wr).SetWriteDeadline(time.Time{})
+
+	wr.Header().Set("Content-Type", "text/event-stream")
+	wr.Header().Set("Cache-Control", "no-cache")
+	wr.Header().Set("Connection", "keep-alive")
+	wr.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	w := newWatcher(h, nil, h.parseEventID(r.Header.Get("Last-Event-ID")))
+	select {
+	case h.joins <- w:
+	case <-h.done:
+		return nil
+	}
+	defer h.LeaveWatcher(w)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case f, ok := <-w.Receive:
+			if !ok {
+				return nil
+			}
+			_, err := fmt.Fprintf(wr, "id: %d-%d\ndata: %s\n\n", h.backlog.epoch, f.seq, f.msg)
+			if err != nil {
+				return nil
+			}
+		case <-ticker.C:
+			_, err := fmt.Fprint(wr, ": ping\n\n")
+			if err != nil {
+				return nil
+			}
+		case <-r.Context().Done():
+			return nil
+		}
+		flusher.Flush()
+	}
+}
+
+// parseEventID returns the seq of a stream event ID sent by a reconnecting client, or 0 if the ID
+// is missing, malformed or from another Hub.
+func (h *Hub) parseEventID(id string) int64 {
+	epoch, seq, ok := strings.Cut(id, "-")
+	if !ok || epoch != strconv.FormatInt(h.backlog.epoch, 10) {
+		return 0
+	}
+	n, err := strconv.ParseInt(seq, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
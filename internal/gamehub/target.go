@@ -0,0 +1,72 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/data"
+	"slices"
+)
+
+// checkTarget watches the score of a target game after each executed event. Once a team is a
+// point away from winning, keepers and watchers are told the game is at game point, and once a
+// team reaches the game's ScoreTarget, winning by two points if its rules say so, the winner is
+// announced. The game is only ended once a keeper confirms it with a GameEndEvent, so the basket
+// that won it can still be undone, which withdraws the winner.
+func (h *Hub) checkTarget() {
+	if h.Game.Type != data.GameTypeTarget || h.Game.ScoreTarget == nil {
+		return
+	}
+	home, away := h.Stats.GetScore()
+	score := envelope{"home": home, "away": away}
+
+	if side, ok := h.targetWinner(home, away); ok {
+		if h.winner != nil && *h.winner == side {
+			return
+		}
+		h.winner = &side
+		h.gamePoint = nil
+
+		msg := h.message(msgWinner, envelope{"side": side, "score": score})
+		h.ToAllKeepers(msg)
+		h.ToAllWatchers(msg)
+		return
+	}
+	if h.winner != nil {
+		h.winner = nil
+		msg := h.message(msgWinner, envelope{"side": nil, "score": score})
+		h.ToAllKeepers(msg)
+		h.ToAllWatchers(msg)
+	}
+
+	gamePoint := make([]data.GameTeamSide, 0, 2)
+	if _, ok := h.targetWinner(home+1, away); ok {
+		gamePoint = append(gamePoint, data.TeamHome)
+	}
+	if _, ok := h.targetWinner(home, away+1); ok {
+		gamePoint = append(gamePoint, data.TeamAway)
+	}
+	if slices.Equal(gamePoint, h.gamePoint) {
+		return
+	}
+	h.gamePoint = gamePoint
+
+	msg := h.message(msgGamePoint, envelope{"sides": gamePoint, "score": score})
+	h.ToAllKeepers(msg)
+	h.ToAllWatchers(msg)
+}
+
+// targetWinner returns the side that has won a target game with the given score, if either has.
+func (h *Hub) targetWinner(home, away int) (data.GameTeamSide, bool) {
+	target := int(*h.Game.ScoreTarget)
+	margin := 1
+	if h.Game.WinByTwo {
+		margin = 2
+	}
+
+	switch {
+	case home >= target && home-away >= margin:
+		return data.TeamHome, true
+	case away >= target && away-home >= margin:
+		return data.TeamAway, true
+	default:
+		return 0, false
+	}
+}
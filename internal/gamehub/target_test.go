@@ -0,0 +1,77 @@
+package gamehub
+
+import (
+	"ScoreTableApi/internal/assert"
+	"ScoreTableApi/internal/data"
+	"ScoreTableApi/internal/stats"
+	"slices"
+	"testing"
+)
+
+func TestTargetWinner(t *testing.T) {
+	tests := []struct {
+		name     string
+		winByTwo bool
+		home     int
+		away     int
+		side     data.GameTeamSide
+		won      bool
+	}{
+		{name: "Below Target", home: 20, away: 19},
+		{name: "Home Reaches Target", home: 21, away: 19, side: data.TeamHome, won: true},
+		{name: "Away Reaches Target", home: 3, away: 21, side: data.TeamAway, won: true},
+		{name: "Past Target", home: 22, away: 21, side: data.TeamHome, won: true},
+		{name: "Win By Two Not Yet", winByTwo: true, home: 21, away: 20},
+		{name: "Win By Two Past Target", winByTwo: true, home: 24, away: 22, side: data.TeamHome,
+			won: true},
+		{name: "Win By Two Away", winByTwo: true, home: 23, away: 25, side: data.TeamAway,
+			won: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := int64(21)
+			h := &Hub{Game: &data.Game{ScoreTarget: &target, WinByTwo: tt.winByTwo}}
+
+			side, won := h.targetWinner(tt.home, tt.away)
+			assert.Equal(t, won, tt.won)
+			assert.Equal(t, side, tt.side)
+		})
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	h := newTestHub(t)
+	target := int64(5)
+	h.Game.Type = data.GameTypeTarget
+	h.Game.ScoreTarget = &target
+
+	run := func(e GameEvent) {
+		t.Helper()
+		_, err := execute(h, e)
+		assert.NilError(t, err)
+		h.checkTarget()
+	}
+
+	run(statEvent("h1", stats.TwoPointMade))
+	run(statEvent("h1", stats.TwoPointMade))
+	assert.Equal(t, slices.Equal(h.gamePoint, []data.GameTeamSide{data.TeamHome}), true)
+	assert.Equal(t, h.winner == nil, true)
+
+	// Reaching the target announces the winner, but the game waits for a keeper to end it.
+	run(statEvent("h1", stats.TwoPointMade))
+	assert.Equal(t, *h.winner, data.TeamHome)
+	assert.Equal(t, h.ended, false)
+
+	// The winning basket can still be undone.
+	run(&GameUndoEvent{})
+	assert.Equal(t, h.winner == nil, true)
+	assert.Equal(t, slices.Equal(h.gamePoint, []data.GameTeamSide{data.TeamHome}), true)
+	home, _ := h.Stats.GetScore()
+	assert.Equal(t, home, 4)
+
+	run(statEvent("h1", stats.Point))
+	assert.Equal(t, *h.winner, data.TeamHome)
+	run(&GameEndEvent{})
+	assert.Equal(t, h.ended, true)
+}
@@ -16,7 +16,22 @@ const (
 	pingPeriod = (pongWait * 9) / 10
 
 	// Maximum message size allowed from peer.
-	maxMessageSize = 512
+	maxMessageSize = 1024
+
+	// Maximum number of stat changes in a compound event.
+	maxCompoundStats = 5
+
+	// Number of executed events that can be queued for the event log before the hub blocks.
+	recorderBufferSize = 256
+
+	// Number of frames broadcast to watchers that are kept so reconnecting watchers can resume.
+	backlogSize = 256
+
+	// Number of frames that can be queued for a watcher before it is dropped as too slow.
+	watcherBufferSize = 64
+
+	// Number of messages that can be queued for a keeper before it is dropped as too slow.
+	keeperBufferSize = 16
 )
 
 var (
@@ -24,4 +39,9 @@ var (
 	space                    = []byte{' '}
 	ErrEventParseFailed      = errors.New("could not parse game event")
 	ErrEventValidationFailed = errors.New("event validation failed")
+	ErrPlayerNotActive       = errors.New("player is not in the active lineup")
+	ErrPlayerNotOnBench      = errors.New("player is not on the bench")
+	ErrPlayerFouledOut       = errors.New("player has fouled out")
+	ErrClockRunning          = errors.New("event cannot be executed while clock is running")
+	ErrStatBelowZero         = errors.New("stat cannot be subtracted below zero")
 )
@@ -1,24 +1,26 @@
 package gamehub
 
 import (
-	"fmt"
 	"github.com/gorilla/websocket"
 	"time"
 )
 
+// Watcher is a subscription to the broadcasts of a Hub. Conn is nil for watchers that are not
+// connected by websocket, such as event streams.
 type Watcher struct {
 	Hub     *Hub
 	Conn    *websocket.Conn
-	Receive chan []byte
-	Error   chan error
+	Receive chan frame
+	// lastSeq is the seq of the last frame received before the watcher reconnected, or 0.
+	lastSeq int64
 }
 
-func newWatcher(hub *Hub, conn *websocket.Conn) *Watcher {
+func newWatcher(hub *Hub, conn *websocket.Conn, lastSeq int64) *Watcher {
 	return &Watcher{
 		Hub:     hub,
 		Conn:    conn,
-		Receive: make(chan []byte),
-		Error:   make(chan error),
+		Receive: make(chan frame, backlogSize+watcherBufferSize),
+		lastSeq: lastSeq,
 	}
 }
 
@@ -29,9 +31,7 @@ func (w *Watcher) WriteEvents() {
 	}()
 	for {
 		select {
-		case message, ok := <-w.Receive:
-
-			fmt.Printf("event from writeEvent: %v", message)
+		case f, ok := <-w.Receive:
 			w.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				// The hub closed the channel.
@@ -43,13 +43,13 @@ func (w *Watcher) WriteEvents() {
 			if err != nil {
 				return
 			}
-			writer.Write(message)
+			writer.Write(f.msg)
 
 			// Add queued chat messages to the current websocket message.
 			n := len(w.Receive)
 			for i := 0; i < n; i++ {
 				writer.Write(newline)
-				writer.Write(<-w.Receive)
+				writer.Write((<-w.Receive).msg)
 			}
 
 			if err := writer.Close(); err != nil {
@@ -60,15 +60,30 @@ func (w *Watcher) WriteEvents() {
 			if err := w.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
-		case closeErr := <-w.Error:
-			closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, closeErr.Error())
-			writer, err := w.Conn.NextWriter(websocket.CloseMessage)
-			if err != nil {
+		}
+	}
+}
+
+// ReadEvents reads messages from the watcher's connection, asking the hub to resync the watcher
+// when it requests it. It returns when the connection is closed.
+func (w *Watcher) ReadEvents() {
+	w.Conn.SetReadLimit(maxMessageSize)
+	_ = w.Conn.SetReadDeadline(time.Now().Add(pongWait))
+	w.Conn.SetPongHandler(func(string) error {
+		_ = w.Conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+	for {
+		_, msg, err := w.Conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if isResync(msg) {
+			select {
+			case w.Hub.resyncs <- w:
+			case <-w.Hub.done:
 				return
 			}
-			writer.Write(closeMessage)
-			writer.Close()
-			return
 		}
 	}
 }
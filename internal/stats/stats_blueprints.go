@@ -8,11 +8,11 @@ var (
 		FieldGoalsMade, FieldGoalPercent, FreeThrowsAttempted, FreeThrowsMade, FreeThrowPercent,
 		TwosAttempted, TwosMade, TwoPointPercent, ThreesAttempted, ThreesMade, ThreePointPercent,
 		ReboundsCompound, DefensiveRebounds, OffensiveRebounds, Steals, Blocks, Assists, Turnovers,
-		FoulsSimple}
+		FoulsSimple, Minutes}
 	Simple Blueprint = []GameStat{PointsSimple, ReboundsSimple, Steals, Blocks, Assists,
-		Turnovers, FoulsSimple}
+		Turnovers, FoulsSimple, Minutes}
 	NoMisses Blueprint = []GameStat{PointsCompound, FieldGoalsMade, FreeThrowsMade,
-		TwosMade, ThreesMade, ReboundsSimple, Steals, Blocks, Assists, FoulsSimple}
+		TwosMade, ThreesMade, ReboundsSimple, Steals, Blocks, Assists, FoulsSimple, Minutes}
 )
 
 // PRIMITIVE STATS
@@ -32,6 +32,7 @@ const (
 	Rebound          PrimitiveStat = "Reb"
 	Turnover         PrimitiveStat = "To"
 	Foul             PrimitiveStat = "Fl"
+	SecondsPlayed    PrimitiveStat = "Sec"
 )
 
 // PLAYER STATS
@@ -47,12 +48,19 @@ var (
 		},
 		req: []PrimitiveStat{FreeThrowMade, TwoPointMade, ThreePointMade},
 	}
+	// playerPointSimple counts points scored without a shot type along with made shots, so a
+	// simple statline can be kept with or without shots.
 	playerPointSimple = playerStat{
 		name: "Pts",
 		getFunc: func(primStats *PrimitiveStatline) any {
-			return primStats.get(Point)
+			var points int
+			points += primStats.get(Point)
+			points += primStats.get(FreeThrowMade)
+			points += primStats.get(TwoPointMade) * 2
+			points += primStats.get(ThreePointMade) * 3
+			return points
 		},
-		req: []PrimitiveStat{Point},
+		req: []PrimitiveStat{Point, FreeThrowMade, TwoPointMade, ThreePointMade},
 	}
 	playerTwoPointAttempt = playerStat{
 		name: "2PtA",
@@ -224,6 +232,13 @@ var (
 		},
 		req: []PrimitiveStat{Foul},
 	}
+	playerMinutes = playerStat{
+		name: "Min",
+		getFunc: func(primStats *PrimitiveStatline) any {
+			return secondsToMinutes(primStats.get(SecondsPlayed))
+		},
+		req: []PrimitiveStat{SecondsPlayed},
+	}
 )
 
 // TEAM STATS
@@ -485,6 +500,17 @@ var (
 		},
 		req: []playerStat{playerFoulSimple},
 	}
+	teamMinutes = teamStat{
+		name: "Min",
+		getFunc: func(teamPlayersStats teamPlayersStatline) any {
+			var sec int
+			for _, sl := range teamPlayersStats {
+				sec += sl.primStats.get(SecondsPlayed)
+			}
+			return secondsToMinutes(sec)
+		},
+		req: []playerStat{playerMinutes},
+	}
 )
 
 // GAME STATS
@@ -739,4 +765,18 @@ var (
 		},
 		req: []teamStat{teamFoulSimple},
 	}
+	Minutes = GameStat{
+		name: "Min",
+		getFunc: func(gameTeamsStats gameTeamsStatline) any {
+			var sec int
+			for _, sl := range gameTeamsStats.home.playerStats {
+				sec += sl.primStats.get(SecondsPlayed)
+			}
+			for _, sl := range gameTeamsStats.away.playerStats {
+				sec += sl.primStats.get(SecondsPlayed)
+			}
+			return secondsToMinutes(sec)
+		},
+		req: []teamStat{teamMinutes},
+	}
 )
@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"ScoreTableApi/internal/assert"
+	"testing"
+)
+
+func TestSimplePoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		adds   map[PrimitiveStat]int
+		points int
+	}{
+		{name: "None", points: 0},
+		{name: "Points Only", adds: map[PrimitiveStat]int{Point: 5}, points: 5},
+		{name: "Free Throws", adds: map[PrimitiveStat]int{FreeThrowMade: 3}, points: 3},
+		{name: "Twos", adds: map[PrimitiveStat]int{TwoPointMade: 2}, points: 4},
+		{name: "Threes", adds: map[PrimitiveStat]int{ThreePointMade: 2}, points: 6},
+		{name: "Misses", adds: map[PrimitiveStat]int{TwoPointMiss: 4, ThreePointMiss: 2,
+			FreeThrowMiss: 1}, points: 0},
+		{name: "Mixed", adds: map[PrimitiveStat]int{Point: 2, FreeThrowMade: 1, TwoPointMade: 1,
+			ThreePointMade: 1}, points: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gsl := NewGameStatline([]string{"h1"}, []string{"a1"}, Simple)
+			for stat, n := range tt.adds {
+				gsl.Add("h1", stat, n)
+			}
+
+			assert.Equal(t, gsl.GetPlayerStat("h1", "Pts"), any(tt.points))
+			home, away := gsl.GetScore()
+			assert.Equal(t, home, tt.points)
+			assert.Equal(t, away, 0)
+		})
+	}
+
+	t.Run("Delta", func(t *testing.T) {
+		gsl := NewGameStatline([]string{"h1"}, []string{"a1"}, Simple)
+		gsl.Add("a1", ThreePointMade, 1)
+
+		dto := gsl.GetDtoFromPrimitive("a1", ThreePointMade)
+		assert.Equal(t, dto.GameStats["Pts"], any(3))
+		assert.Equal(t, dto.Teams.Away.TeamStats["Pts"], any(3))
+		assert.Equal(t, dto.Teams.Away.PlayerStats["a1"]["Pts"], any(3))
+	})
+}
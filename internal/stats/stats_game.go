@@ -43,6 +43,35 @@ func (gsl *GameStatline) Add(playerPin string, stat PrimitiveStat, add int) int
 	return newValue
 }
 
+// GetPrimitive returns the current value of a PrimitiveStat for provided playerPin.
+func (gsl *GameStatline) GetPrimitive(playerPin string, stat PrimitiveStat) int {
+	statline, ok := gsl.playerStats[playerPin]
+	if !ok {
+		return 0
+	}
+	return statline.primStats.get(stat)
+}
+
+// GetPlayerStat returns the value of the player Stat with name for provided playerPin, or nil if
+// the player or Stat is not in the GameStatline.
+func (gsl *GameStatline) GetPlayerStat(playerPin string, name string) any {
+	statline, ok := gsl.playerStats[playerPin]
+	if !ok {
+		return nil
+	}
+	stat, ok := statline.stats[name]
+	if !ok {
+		return nil
+	}
+	return statline.get(stat)
+}
+
+// GetScore returns the points of the home and away teams. A team's points are 0 if the Blueprint
+// has no "Pts" Stat.
+func (gsl *GameStatline) GetScore() (home, away int) {
+	return gsl.teamStats.home.points(), gsl.teamStats.away.points()
+}
+
 // GetDtoFromPrimitive return a GameStatlineDto containing only Stat's that are dependent
 // on provided PrimitiveStat.
 func (gsl *GameStatline) GetDtoFromPrimitive(playerPin string, stat PrimitiveStat) GameStatlineDto {
@@ -195,6 +224,43 @@ type GameStatlineDto struct {
 	} `json:"teams"`
 }
 
+// Merge adds the Stat's of other to the GameStatlineDto, replacing any it already has.
+func (dto *GameStatlineDto) Merge(other GameStatlineDto) {
+	dto.GameStats = mergeStatlineDto(dto.GameStats, other.GameStats)
+	dto.Teams.Home.TeamStats = mergeStatlineDto(dto.Teams.Home.TeamStats, other.Teams.Home.TeamStats)
+	dto.Teams.Away.TeamStats = mergeStatlineDto(dto.Teams.Away.TeamStats, other.Teams.Away.TeamStats)
+	dto.Teams.Home.PlayerStats = mergePlayerStatlineDtos(dto.Teams.Home.PlayerStats,
+		other.Teams.Home.PlayerStats)
+	dto.Teams.Away.PlayerStats = mergePlayerStatlineDtos(dto.Teams.Away.PlayerStats,
+		other.Teams.Away.PlayerStats)
+}
+
+func mergeStatlineDto(dst, src statlineDto) statlineDto {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = statlineDto{}
+	}
+	for n, v := range src {
+		dst[n] = v
+	}
+	return dst
+}
+
+func mergePlayerStatlineDtos(dst, src map[string]statlineDto) map[string]statlineDto {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]statlineDto)
+	}
+	for p, sl := range src {
+		dst[p] = mergeStatlineDto(dst[p], sl)
+	}
+	return dst
+}
+
 func (gsl *GameStatline) getAll() map[string]any {
 	statline := make(map[string]any)
 	for n, s := range gsl.stats {
@@ -16,6 +16,11 @@ func float64ToPercent(value float64) string {
 	}
 }
 
+// secondsToMinutes formats a number of seconds played as minutes and seconds, e.g. "12:05".
+func secondsToMinutes(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
 func assertAndCopyStatsToMap[T Stat](stats []Stat) map[string]T {
 	asserted := make(map[string]T)
 	for _, s := range stats {
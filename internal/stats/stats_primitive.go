@@ -61,6 +61,18 @@ func (ps PrimitiveStat) getName() string {
 	return string(ps)
 }
 
+// IsPrimitive reports whether stat is one of the PrimitiveStat's a statline can be written to.
+func IsPrimitive(stat PrimitiveStat) bool {
+	switch stat {
+	case Point, ThreePointMiss, ThreePointMade, TwoPointMiss, TwoPointMade, FreeThrowMiss,
+		FreeThrowMade, Assist, Block, Steal, OffensiveRebound, DefensiveRebound, Rebound, Turnover,
+		Foul, SecondsPlayed:
+		return true
+	default:
+		return false
+	}
+}
+
 // PrimitiveStatline holds a map with keys of type PrimitiveStat and value of type int. Int value
 // holds current value of stat.
 type PrimitiveStatline struct {
@@ -46,6 +46,15 @@ func (ps *teamStatline) getAll() map[string]any {
 	return statline
 }
 
+func (ps *teamStatline) points() int {
+	stat, ok := ps.stats["Pts"]
+	if !ok {
+		return 0
+	}
+	points, _ := ps.get(stat).(int)
+	return points
+}
+
 func newTeamStatline(playerPins []string, side TeamSide, teamStats []teamStat) teamStatline {
 	statline := teamStatline{
 		stats: make(map[string]teamStat),
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS game_events;
\ No newline at end of file
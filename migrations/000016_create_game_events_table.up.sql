@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS game_events (
+    id bigserial PRIMARY KEY,
+    game_id bigint NOT NULL REFERENCES games ON DELETE CASCADE,
+    seq bigint NOT NULL,
+    user_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    period integer NOT NULL,
+    clock text NOT NULL,
+    type integer NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT game_events_game_id_seq_unq UNIQUE (game_id, seq)
+);
\ No newline at end of file
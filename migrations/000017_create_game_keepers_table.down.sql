@@ -0,0 +1 @@
+DROP TABLE IF EXISTS game_keepers;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS game_keepers (
+    game_id bigint NOT NULL REFERENCES games ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    created_at timestamp(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (game_id, user_id)
+);
\ No newline at end of file
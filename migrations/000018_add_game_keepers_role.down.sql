@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS game_keepers
+DROP COLUMN IF EXISTS role;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS game_keepers
+ADD COLUMN role text NOT NULL DEFAULT 'all';
\ No newline at end of file
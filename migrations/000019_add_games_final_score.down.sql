@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    DROP COLUMN IF EXISTS home_score,
+    DROP COLUMN IF EXISTS away_score,
+    DROP COLUMN IF EXISTS box_score;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    ADD COLUMN home_score integer,
+    ADD COLUMN away_score integer,
+    ADD COLUMN box_score jsonb;
\ No newline at end of file
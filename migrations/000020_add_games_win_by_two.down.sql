@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS games
+DROP COLUMN IF EXISTS win_by_two;
\ No newline at end of file
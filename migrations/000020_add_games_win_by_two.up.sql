@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS games
+ADD COLUMN win_by_two boolean NOT NULL DEFAULT false;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    DROP COLUMN IF EXISTS bonus_fouls,
+    DROP COLUMN IF EXISTS double_bonus_fouls,
+    DROP COLUMN IF EXISTS foul_limit;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    ADD COLUMN bonus_fouls integer NOT NULL DEFAULT 7,
+    ADD COLUMN double_bonus_fouls integer NOT NULL DEFAULT 10,
+    ADD COLUMN foul_limit integer NOT NULL DEFAULT 5;
\ No newline at end of file
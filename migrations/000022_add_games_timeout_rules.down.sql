@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS games
+    DROP COLUMN IF EXISTS timeouts_per,
+    DROP COLUMN IF EXISTS timeouts,
+    DROP COLUMN IF EXISTS short_timeouts,
+    DROP COLUMN IF EXISTS timeout_length,
+    DROP COLUMN IF EXISTS short_timeout_length,
+    DROP COLUMN IF EXISTS ot_length,
+    DROP COLUMN IF EXISTS overtimes_before_tie;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS games
+    ADD COLUMN timeouts_per text NOT NULL DEFAULT 'game',
+    ADD COLUMN timeouts integer NOT NULL DEFAULT 4,
+    ADD COLUMN short_timeouts integer NOT NULL DEFAULT 0,
+    ADD COLUMN timeout_length bigint NOT NULL DEFAULT 60000000000,
+    ADD COLUMN short_timeout_length bigint NOT NULL DEFAULT 30000000000,
+    ADD COLUMN ot_length bigint,
+    ADD COLUMN overtimes_before_tie integer;
\ No newline at end of file
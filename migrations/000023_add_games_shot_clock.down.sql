@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS games
+    DROP COLUMN IF EXISTS shot_clock_length,
+    DROP COLUMN IF EXISTS shot_clock_short;
\ No newline at end of file
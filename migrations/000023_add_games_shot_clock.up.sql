@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS games
+    ADD COLUMN shot_clock_length bigint,
+    ADD COLUMN shot_clock_short bigint;
\ No newline at end of file
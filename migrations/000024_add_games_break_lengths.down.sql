@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    DROP COLUMN IF EXISTS break_length,
+    DROP COLUMN IF EXISTS halftime_length,
+    DROP COLUMN IF EXISTS ot_break_length;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    ADD COLUMN break_length bigint,
+    ADD COLUMN halftime_length bigint,
+    ADD COLUMN ot_break_length bigint;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    DROP COLUMN IF EXISTS running_clock,
+    DROP COLUMN IF EXISTS stop_clock_under,
+    DROP COLUMN IF EXISTS stop_clock_margin;
\ No newline at end of file
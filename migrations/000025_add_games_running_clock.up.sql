@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS games
+    ADD COLUMN running_clock boolean NOT NULL DEFAULT false,
+    ADD COLUMN stop_clock_under bigint,
+    ADD COLUMN stop_clock_margin integer;
\ No newline at end of file
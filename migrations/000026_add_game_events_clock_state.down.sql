@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS game_events
+    DROP COLUMN IF EXISTS clock_state;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS game_events
+    ADD COLUMN clock_state integer NOT NULL DEFAULT 0;
\ No newline at end of file